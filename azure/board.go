@@ -0,0 +1,247 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+// Fields holding the position of a work item on the board of the default team
+const (
+	FieldBoardColumn     = "System.BoardColumn"
+	FieldBoardColumnDone = "System.BoardColumnDone"
+	FieldBoardLane       = "System.BoardLane"
+)
+
+// Types of board columns
+const (
+	ColumnIncoming   = "incoming"
+	ColumnInProgress = "inProgress"
+	ColumnOutgoing   = "outgoing"
+)
+
+// Board is the Kanban board of a backlog level as configured by the team
+type Board struct {
+	ID      string
+	Name    string
+	Columns []BoardColumn
+	// Lanes are the swimlane names, where "" is the default lane
+	Lanes []string
+	// ColumnField, DoneField and LaneField are the fields holding the position of
+	// work items on this team's board. Moves are written to them, as the System
+	// board fields only reflect the board of the default team and are read-only.
+	ColumnField string
+	DoneField   string
+	LaneField   string
+}
+
+// BoardColumn is a column of a board
+type BoardColumn struct {
+	Name string
+	Type string
+	// ItemLimit is the WIP limit of the column, or 0 when it has none
+	ItemLimit int
+	// IsSplit reports whether the column is split into Doing and Done
+	IsSplit bool
+	// StateMappings maps work item types to the state of their work items in the column
+	StateMappings map[WorkItemType]string
+}
+
+// Types returns the work item types shown on the board
+func (b *Board) Types() []WorkItemType {
+	var types []WorkItemType
+	for _, column := range b.Columns {
+		for itemType := range column.StateMappings {
+			if !slices.Contains(types, itemType) {
+				types = append(types, itemType)
+			}
+		}
+	}
+	slices.Sort(types)
+	return types
+}
+
+// Fields returns the fields to fetch to place work items on the board
+func (b *Board) Fields() []string {
+	return []string{FieldBoardColumn, FieldBoardColumnDone, FieldBoardLane, b.ColumnField, b.DoneField, b.LaneField}
+}
+
+// Position returns the column of a work item and whether it is in the Done half of
+// a split column. Work items that have not been placed on the board yet are in the
+// first column their state maps to. It returns -1 for work items not on the board.
+func (b *Board) Position(item WorkItem) (int, bool) {
+	column, _ := b.field(item, b.ColumnField, FieldBoardColumn).(string)
+	col := slices.IndexFunc(b.Columns, func(c BoardColumn) bool { return c.Name == column })
+	if col < 0 || b.Columns[col].StateMappings[item.Type] != item.State {
+		col = slices.IndexFunc(b.Columns, func(c BoardColumn) bool {
+			return c.StateMappings[item.Type] == item.State
+		})
+	}
+	if col < 0 {
+		return -1, false
+	}
+	done, _ := b.field(item, b.DoneField, FieldBoardColumnDone).(bool)
+	return col, done && b.Columns[col].IsSplit
+}
+
+// Lane returns the swimlane of a work item, "" for the default lane
+func (b *Board) Lane(item WorkItem) string {
+	lane, _ := b.field(item, b.LaneField, FieldBoardLane).(string)
+	if !slices.Contains(b.Lanes, lane) {
+		return ""
+	}
+	return lane
+}
+
+// field returns the value of the team's board field, or of the default team's field
+func (b *Board) field(item WorkItem, ref, fallback string) any {
+	if value, ok := item.Fields[ref]; ok {
+		return value
+	}
+	return item.Fields[fallback]
+}
+
+// Move returns the patch operations that move a work item to a column of the board,
+// changing its state to the one the column maps its type to
+func (b *Board) Move(item WorkItem, col int, done bool) ([]PatchOperation, error) {
+	column := b.Columns[col]
+	state, ok := column.StateMappings[item.Type]
+	if !ok {
+		return nil, fmt.Errorf("%s work items cannot be placed in the %s column", item.Type, column.Name)
+	}
+
+	ops := []PatchOperation{SetField(b.ColumnField, column.Name)}
+	if column.IsSplit {
+		ops = append(ops, SetField(b.DoneField, done))
+	}
+	if state != item.State {
+		ops = append(ops, SetField(FieldState, state))
+	}
+	return ops, nil
+}
+
+// boardsResponse represents the boards of a team
+type boardsResponse struct {
+	Count int `json:"count"`
+	Value []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"value"`
+}
+
+// boardResponse represents the configuration of a board
+type boardResponse struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Columns []struct {
+		Name          string            `json:"name"`
+		ColumnType    string            `json:"columnType"`
+		ItemLimit     int               `json:"itemLimit"`
+		IsSplit       bool              `json:"isSplit"`
+		StateMappings map[string]string `json:"stateMappings"`
+	} `json:"columns"`
+	Rows []struct {
+		Name *string `json:"name"`
+	} `json:"rows"`
+	Fields struct {
+		ColumnField struct {
+			ReferenceName string `json:"referenceName"`
+		} `json:"columnField"`
+		DoneField struct {
+			ReferenceName string `json:"referenceName"`
+		} `json:"doneField"`
+		RowField struct {
+			ReferenceName string `json:"referenceName"`
+		} `json:"rowField"`
+	} `json:"fields"`
+}
+
+// teamURL returns the URL of a team-scoped API
+func (c *AzureClient) teamURL(team, path string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/_apis/%s",
+		c.BaseURL,
+		url.PathEscape(c.Organization),
+		url.PathEscape(c.Project),
+		url.PathEscape(team),
+		path)
+}
+
+// GetBoards returns the names of the team's boards, one per backlog level
+func (c *AzureClient) GetBoards() ([]string, error) {
+	team, err := c.team()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", c.teamURL(team, "work/boards?api-version=7.0"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var boardsResp boardsResponse
+	if err := c.do(req, &boardsResp); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(boardsResp.Value))
+	for i, board := range boardsResp.Value {
+		names[i] = board.Name
+	}
+	return names, nil
+}
+
+// GetBoard returns the columns, swimlanes and fields of one of the team's boards
+func (c *AzureClient) GetBoard(name string) (*Board, error) {
+	team, err := c.team()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", c.teamURL(team, "work/boards/"+url.PathEscape(name)+"?api-version=7.0"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var boardResp boardResponse
+	if err := c.do(req, &boardResp); err != nil {
+		return nil, err
+	}
+
+	board := &Board{
+		ID:          boardResp.ID,
+		Name:        boardResp.Name,
+		ColumnField: boardResp.Fields.ColumnField.ReferenceName,
+		DoneField:   boardResp.Fields.DoneField.ReferenceName,
+		LaneField:   boardResp.Fields.RowField.ReferenceName,
+	}
+	if board.ColumnField == "" {
+		board.ColumnField, board.DoneField, board.LaneField = FieldBoardColumn, FieldBoardColumnDone, FieldBoardLane
+	}
+	for _, col := range boardResp.Columns {
+		column := BoardColumn{
+			Name:          col.Name,
+			Type:          col.ColumnType,
+			ItemLimit:     col.ItemLimit,
+			IsSplit:       col.IsSplit,
+			StateMappings: make(map[WorkItemType]string, len(col.StateMappings)),
+		}
+		for itemType, state := range col.StateMappings {
+			column.StateMappings[WorkItemType(itemType)] = state
+		}
+		board.Columns = append(board.Columns, column)
+	}
+	for _, row := range boardResp.Rows {
+		// The default lane has no name
+		name := ""
+		if row.Name != nil {
+			name = *row.Name
+		}
+		board.Lanes = append(board.Lanes, name)
+	}
+	return board, nil
+}
@@ -0,0 +1,86 @@
+package azure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// TestGetBoard checks that columns, lanes and the board's fields are read
+func TestGetBoard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/project/project Team/_apis/work/boards/Stories" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{
+			"id": "b1", "name": "Stories",
+			"columns": [
+				{"name": "New", "columnType": "incoming", "itemLimit": 0, "stateMappings": {"User Story": "New"}},
+				{"name": "Doing", "columnType": "inProgress", "itemLimit": 3, "isSplit": true, "stateMappings": {"User Story": "Active"}}
+			],
+			"rows": [{"id": "r0", "name": null}, {"id": "r1", "name": "Expedite"}],
+			"fields": {
+				"columnField": {"referenceName": "WEF_1_Kanban.Column"},
+				"doneField": {"referenceName": "WEF_1_Kanban.Column.Done"},
+				"rowField": {"referenceName": "WEF_1_Kanban.Lane"}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+	client.Team = "project Team"
+
+	board, err := client.GetBoard("Stories")
+	if err != nil {
+		t.Fatalf("GetBoard failed: %v", err)
+	}
+	if len(board.Columns) != 2 || board.Columns[1].ItemLimit != 3 || !board.Columns[1].IsSplit ||
+		board.Columns[1].StateMappings[UserStory] != "Active" {
+		t.Errorf("columns = %+v", board.Columns)
+	}
+	if !slices.Equal(board.Lanes, []string{"", "Expedite"}) {
+		t.Errorf("lanes = %q, want default and Expedite", board.Lanes)
+	}
+	if board.ColumnField != "WEF_1_Kanban.Column" || board.DoneField != "WEF_1_Kanban.Column.Done" || board.LaneField != "WEF_1_Kanban.Lane" {
+		t.Errorf("fields = %q, %q, %q", board.ColumnField, board.DoneField, board.LaneField)
+	}
+}
+
+// TestBoardPositionAndMove checks where work items are placed on a board and
+// the operations that move them
+func TestBoardPositionAndMove(t *testing.T) {
+	board, err := NewMockAzureClient().GetBoard("Stories")
+	if err != nil {
+		t.Fatalf("GetBoard failed: %v", err)
+	}
+
+	item := WorkItem{ID: 1, Type: UserStory, State: "Active"}
+	if col, done := board.Position(item); col != 1 || done {
+		t.Errorf("unplaced active story at column %d (done %v), want 1", col, done)
+	}
+	item.Fields = map[string]any{mockColumnField: "Active", mockDoneField: true, mockLaneField: "Expedite"}
+	if col, done := board.Position(item); col != 1 || !done {
+		t.Errorf("done active story at column %d (done %v), want the done half of 1", col, done)
+	}
+	if lane := board.Lane(item); lane != "Expedite" {
+		t.Errorf("lane = %q, want Expedite", lane)
+	}
+
+	ops, err := board.Move(item, 2, false)
+	if err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	want := []PatchOperation{SetField(mockColumnField, "Testing"), SetField(FieldState, "In Progress")}
+	if !slices.Equal(ops, want) {
+		t.Errorf("ops = %+v, want %+v", ops, want)
+	}
+
+	bug := WorkItem{ID: 2, Type: Bug, State: "Resolved"}
+	if _, err := board.Move(bug, 3, false); err == nil {
+		t.Error("moved a bug to a column without a state for bugs")
+	}
+}
@@ -0,0 +1,71 @@
+package azure
+
+import (
+	"slices"
+	"time"
+)
+
+// BurndownDay is the remaining work of an iteration at the end of one of its working days
+type BurndownDay struct {
+	Day time.Time
+	// Remaining is the remaining work of the work items in the iteration at the
+	// end of the day, or at the time of the burndown for the current day
+	Remaining float64
+	// Ideal is the remaining work on the day if the work of the first day was
+	// burned down evenly until the last day
+	Ideal float64
+	// Future reports that the day has not started yet, so Remaining is unknown
+	Future bool
+}
+
+// Burndown computes the remaining work at the end of each working day of an
+// iteration from the revisions of its work items, oldest first. On each day a
+// work item counts with the remaining work of its last revision by the end of the
+// day, if that revision was in the iteration and not in a terminal state.
+func Burndown(capacity *Capacity, revisions map[int][]WorkItem, now time.Time) []BurndownDay {
+	days := capacity.Days()
+	burndown := make([]BurndownDay, len(days))
+	for i, day := range days {
+		burndown[i].Day = day
+		if day.After(dayOf(now)) {
+			burndown[i].Future = true
+			continue
+		}
+		for _, revs := range revisions {
+			if rev := revisionOn(revs, day); rev != nil && countsTowardsBurndown(*rev, capacity.Iteration) {
+				burndown[i].Remaining += rev.RemainingWork
+			}
+		}
+	}
+
+	if len(burndown) > 0 {
+		total := burndown[0].Remaining
+		for i := range burndown {
+			burndown[i].Ideal = total
+			if len(burndown) > 1 {
+				burndown[i].Ideal = total * float64(len(burndown)-1-i) / float64(len(burndown)-1)
+			}
+		}
+	}
+	return burndown
+}
+
+// revisionOn returns the last of the revisions made by the end of day, or nil if
+// the work item did not exist yet
+func revisionOn(revisions []WorkItem, day time.Time) *WorkItem {
+	var last *WorkItem
+	for i := range revisions {
+		// Days are compared in local time, the way the team sees them
+		if dayOf(revisions[i].Changed().Local()).After(day) {
+			break
+		}
+		last = &revisions[i]
+	}
+	return last
+}
+
+// countsTowardsBurndown reports whether the remaining work of a revision counts
+// towards the burndown of an iteration
+func countsTowardsBurndown(rev WorkItem, iteration *ClassificationNode) bool {
+	return UnderPath(rev.Iteration, iteration.Path) && !slices.Contains(TerminalStates, rev.State)
+}
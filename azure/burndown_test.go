@@ -0,0 +1,52 @@
+package azure
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBurndown checks that each day counts the remaining work of the last revision
+// of each work item by the end of the day
+func TestBurndown(t *testing.T) {
+	// Monday March 4th to Friday March 8th
+	capacity := &Capacity{Iteration: &ClassificationNode{
+		Path:       `project\Sprint 1`,
+		StartDate:  time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		FinishDate: time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC),
+	}}
+	rev := func(changed, iteration, state string, remaining float64) WorkItem {
+		return WorkItem{ChangedDate: changed, Iteration: iteration, State: state, RemainingWork: remaining}
+	}
+	revisions := map[int][]WorkItem{
+		1: {
+			rev("2024-03-04 09:00", `project\Sprint 1`, "New", 8),
+			rev("2024-03-05 12:00", `project\Sprint 1`, "Active", 5),
+			rev("2024-03-05 17:00", `project\Sprint 1`, "Active", 4),
+			rev("2024-03-06 10:00", `project\Sprint 1`, "Done", 1),
+		},
+		// Added to the sprint on its second day and moved out on its third
+		2: {
+			rev("2024-03-01 09:00", `project`, "New", 6),
+			rev("2024-03-05 09:00", `project\Sprint 1`, "New", 6),
+			rev("2024-03-06 09:00", `project\Sprint 2`, "New", 6),
+		},
+	}
+
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.Local)
+	burndown := Burndown(capacity, revisions, now)
+	if len(burndown) != 5 {
+		t.Fatalf("got %d days, want 5", len(burndown))
+	}
+	want := []float64{8, 10, 0}
+	for i, remaining := range want {
+		if burndown[i].Future || burndown[i].Remaining != remaining {
+			t.Errorf("day %d: remaining = %v, want %v", i, burndown[i].Remaining, remaining)
+		}
+	}
+	if !burndown[3].Future || !burndown[4].Future {
+		t.Errorf("the last two days should be in the future: %+v", burndown[3:])
+	}
+	if burndown[0].Ideal != 8 || burndown[2].Ideal != 4 || burndown[4].Ideal != 0 {
+		t.Errorf("ideal = %v, %v, %v, want 8, 4, 0", burndown[0].Ideal, burndown[2].Ideal, burndown[4].Ideal)
+	}
+}
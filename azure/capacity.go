@@ -0,0 +1,219 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DateRange is a range of days, including its first and last day
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains reports whether day is one of the days of the range
+func (r DateRange) Contains(day time.Time) bool {
+	day = dayOf(day)
+	return !day.Before(dayOf(r.Start)) && !day.After(dayOf(r.End))
+}
+
+// dayOf returns the date of t at midnight UTC, the way iteration dates are given
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Activity is the time a team member spends on one kind of work each day
+type Activity struct {
+	// Name is the activity, such as Development or Testing, or "" when unspecified
+	Name           string
+	CapacityPerDay float64
+}
+
+// MemberCapacity is the capacity of a team member in an iteration
+type MemberCapacity struct {
+	Member     Identity
+	Activities []Activity
+	DaysOff    []DateRange
+}
+
+// PerDay returns the hours the member works each working day across activities
+func (m MemberCapacity) PerDay() float64 {
+	var total float64
+	for _, activity := range m.Activities {
+		total += activity.CapacityPerDay
+	}
+	return total
+}
+
+// IsOff reports whether the member has the day off
+func (m MemberCapacity) IsOff(day time.Time) bool {
+	return slices.ContainsFunc(m.DaysOff, func(r DateRange) bool { return r.Contains(day) })
+}
+
+// defaultWorkingDays are the days of the week teams work on unless configured otherwise
+var defaultWorkingDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// Capacity is the capacity of the team in an iteration
+type Capacity struct {
+	Iteration   *ClassificationNode
+	Members     []MemberCapacity
+	TeamDaysOff []DateRange
+	// WorkingDays are the days of the week the team works on
+	WorkingDays []time.Weekday
+}
+
+// Days returns the days of the iteration the team works on, leaving out the
+// team's days off. Unscheduled iterations have no days.
+func (c *Capacity) Days() []time.Time {
+	if c.Iteration.StartDate.IsZero() || c.Iteration.FinishDate.IsZero() {
+		return nil
+	}
+	workingDays := c.WorkingDays
+	if len(workingDays) == 0 {
+		workingDays = defaultWorkingDays
+	}
+
+	var days []time.Time
+	for day := dayOf(c.Iteration.StartDate); !day.After(dayOf(c.Iteration.FinishDate)); day = day.AddDate(0, 0, 1) {
+		if !slices.Contains(workingDays, day.Weekday()) {
+			continue
+		}
+		if slices.ContainsFunc(c.TeamDaysOff, func(r DateRange) bool { return r.Contains(day) }) {
+			continue
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// Available returns the hours a member can work from the given day to the end of
+// the iteration, leaving out their days off
+func (c *Capacity) Available(member MemberCapacity, from time.Time) float64 {
+	var days int
+	for _, day := range c.Days() {
+		if !day.Before(dayOf(from)) && !member.IsOff(day) {
+			days++
+		}
+	}
+	return float64(days) * member.PerDay()
+}
+
+// dateRangeResponse represents a range of days off
+type dateRangeResponse struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func convertDateRanges(ranges []dateRangeResponse) []DateRange {
+	var out []DateRange
+	for _, r := range ranges {
+		out = append(out, DateRange{Start: r.Start, End: r.End})
+	}
+	return out
+}
+
+// capacitiesResponse represents the capacity of a team in an iteration, as returned
+// by version 7.0 of the API
+type capacitiesResponse struct {
+	TeamMembers []struct {
+		TeamMember struct {
+			ID          string `json:"id"`
+			DisplayName string `json:"displayName"`
+			UniqueName  string `json:"uniqueName"`
+		} `json:"teamMember"`
+		Activities []struct {
+			Name           string  `json:"name"`
+			CapacityPerDay float64 `json:"capacityPerDay"`
+		} `json:"activities"`
+		DaysOff []dateRangeResponse `json:"daysOff"`
+	} `json:"teamMembers"`
+}
+
+// teamDaysOffResponse represents the days off of a team in an iteration
+type teamDaysOffResponse struct {
+	DaysOff []dateRangeResponse `json:"daysOff"`
+}
+
+// teamSettingsResponse represents the settings of a team
+type teamSettingsResponse struct {
+	WorkingDays []string `json:"workingDays"`
+}
+
+// weekdays maps the day names used by the team settings API to weekdays
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// GetCapacity returns the capacity and days off of the team's members in an
+// iteration, along with the team's days off and working days
+func (c *AzureClient) GetCapacity(iteration *ClassificationNode) (*Capacity, error) {
+	if iteration.Identifier == "" {
+		return nil, fmt.Errorf("iteration %s has no identifier", iteration.Path)
+	}
+	iterationURL := "work/teamsettings/iterations/" + url.PathEscape(iteration.Identifier)
+
+	var capacitiesResp capacitiesResponse
+	if err := c.getTeamSettings(iterationURL+"/capacities?api-version=7.0", &capacitiesResp); err != nil {
+		return nil, err
+	}
+	var daysOffResp teamDaysOffResponse
+	if err := c.getTeamSettings(iterationURL+"/teamdaysoff?api-version=7.0", &daysOffResp); err != nil {
+		return nil, err
+	}
+	var settingsResp teamSettingsResponse
+	if err := c.getTeamSettings("work/teamsettings?api-version=7.0", &settingsResp); err != nil {
+		return nil, err
+	}
+
+	capacity := &Capacity{
+		Iteration:   iteration,
+		TeamDaysOff: convertDateRanges(daysOffResp.DaysOff),
+	}
+	for _, name := range settingsResp.WorkingDays {
+		if day, ok := weekdays[strings.ToLower(name)]; ok {
+			capacity.WorkingDays = append(capacity.WorkingDays, day)
+		}
+	}
+	for _, member := range capacitiesResp.TeamMembers {
+		mc := MemberCapacity{
+			Member: Identity{
+				ID:          member.TeamMember.ID,
+				DisplayName: member.TeamMember.DisplayName,
+				UniqueName:  member.TeamMember.UniqueName,
+			},
+			DaysOff: convertDateRanges(member.DaysOff),
+		}
+		for _, activity := range member.Activities {
+			mc.Activities = append(mc.Activities, Activity{Name: activity.Name, CapacityPerDay: activity.CapacityPerDay})
+		}
+		capacity.Members = append(capacity.Members, mc)
+	}
+	return capacity, nil
+}
+
+// getTeamSettings fetches a team-scoped resource into out
+func (c *AzureClient) getTeamSettings(path string, out any) error {
+	team, err := c.team()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("GET", c.teamURL(team, path), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	return c.do(req, out)
+}
@@ -0,0 +1,63 @@
+package azure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestGetCapacity checks that member capacity, days off and working days are read
+func TestGetCapacity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/project/project Team/_apis/work/teamsettings/iterations/it-1/capacities":
+			w.Write([]byte(`{
+				"teamMembers": [{
+					"teamMember": {"id": "u1", "displayName": "John Doe", "uniqueName": "john@example.com"},
+					"activities": [{"name": "Development", "capacityPerDay": 4}, {"name": "Testing", "capacityPerDay": 2}],
+					"daysOff": [{"start": "2024-03-06T00:00:00Z", "end": "2024-03-06T00:00:00Z"}],
+					"url": "https://dev.azure.com/org/project/_apis/work/teamsettings/iterations/it-1/capacities/u1"
+				}],
+				"totalCapacityPerDay": 6,
+				"totalDaysOff": 1
+			}`))
+		case "/org/project/project Team/_apis/work/teamsettings/iterations/it-1/teamdaysoff":
+			w.Write([]byte(`{"daysOff": [{"start": "2024-03-08T00:00:00Z", "end": "2024-03-08T00:00:00Z"}]}`))
+		case "/org/project/project Team/_apis/work/teamsettings":
+			w.Write([]byte(`{"workingDays": ["monday", "tuesday", "wednesday", "thursday", "friday"]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+	client.Team = "project Team"
+
+	// A two-week sprint from Monday March 4th to Friday March 15th
+	iteration := &ClassificationNode{
+		Identifier: "it-1",
+		Path:       `project\Sprint 1`,
+		StartDate:  time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		FinishDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+	capacity, err := client.GetCapacity(iteration)
+	if err != nil {
+		t.Fatalf("GetCapacity failed: %v", err)
+	}
+	if len(capacity.Members) != 1 || capacity.Members[0].Member.UniqueName != "john@example.com" || capacity.Members[0].PerDay() != 6 {
+		t.Fatalf("members = %+v", capacity.Members)
+	}
+
+	// Ten weekdays, less the team's day off
+	if days := capacity.Days(); len(days) != 9 {
+		t.Errorf("got %d working days, want 9: %v", len(days), days)
+	}
+	// From Wednesday: seven working days, less the member's day off
+	from := time.Date(2024, 3, 6, 15, 0, 0, 0, time.UTC)
+	if got := capacity.Available(capacity.Members[0], from); got != 36 {
+		t.Errorf("available = %v hours, want 36", got)
+	}
+}
@@ -0,0 +1,244 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ClassificationGroup is one of the trees work items are classified by
+type ClassificationGroup string
+
+const (
+	Areas      ClassificationGroup = "areas"
+	Iterations ClassificationGroup = "iterations"
+)
+
+// ClassificationNode is an area or iteration of the project
+type ClassificationNode struct {
+	ID int
+	// Identifier is the GUID the team settings APIs refer to iterations by
+	Identifier string
+	Name       string
+	// Path is the value of the area or iteration path field of work items in the node
+	Path string
+	// StartDate and FinishDate are the schedule of an iteration, zero when unscheduled
+	StartDate  time.Time
+	FinishDate time.Time
+	Children   []*ClassificationNode
+}
+
+// Find returns the node with the given path among the node and its descendants, or nil
+func (n *ClassificationNode) Find(path string) *ClassificationNode {
+	if strings.EqualFold(n.Path, path) {
+		return n
+	}
+	for _, child := range n.Children {
+		if found := child.Find(path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Contains reports whether the node is on the given day, for scheduled iterations
+func (n *ClassificationNode) Contains(day time.Time) bool {
+	if n.StartDate.IsZero() || n.FinishDate.IsZero() {
+		return false
+	}
+	// Iterations finish at the end of their last day
+	return !day.Before(n.StartDate) && day.Before(n.FinishDate.AddDate(0, 0, 1))
+}
+
+// UnderPath reports whether path is parent or one of its descendants
+func UnderPath(path, parent string) bool {
+	return strings.EqualFold(path, parent) ||
+		len(path) > len(parent) && strings.EqualFold(path[:len(parent)+1], parent+`\`)
+}
+
+// classificationNodeResponse represents an area or iteration returned by the API
+type classificationNodeResponse struct {
+	ID         int    `json:"id"`
+	Identifier string `json:"identifier"`
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Attributes struct {
+		StartDate  time.Time `json:"startDate"`
+		FinishDate time.Time `json:"finishDate"`
+	} `json:"attributes"`
+	Children []classificationNodeResponse `json:"children"`
+}
+
+// fieldPath converts the path of a classification node to the value of the
+// path field, dropping the structure name: \Project\Iteration\Sprint 1 is Project\Sprint 1
+func fieldPath(nodePath string) string {
+	parts := strings.Split(strings.TrimPrefix(nodePath, `\`), `\`)
+	if len(parts) > 1 {
+		parts = append(parts[:1], parts[2:]...)
+	}
+	return strings.Join(parts, `\`)
+}
+
+func convertClassificationNode(n classificationNodeResponse) *ClassificationNode {
+	node := &ClassificationNode{
+		ID:         n.ID,
+		Identifier: n.Identifier,
+		Name:       n.Name,
+		Path:       fieldPath(n.Path),
+		StartDate:  n.Attributes.StartDate,
+		FinishDate: n.Attributes.FinishDate,
+	}
+	for _, child := range n.Children {
+		node.Children = append(node.Children, convertClassificationNode(child))
+	}
+	return node
+}
+
+// maxClassificationDepth is how many levels of areas or iterations are fetched
+const maxClassificationDepth = 10
+
+// GetClassificationNodes returns the area or iteration tree of the project.
+// Trees are cached for the lifetime of the client.
+func (c *AzureClient) GetClassificationNodes(group ClassificationGroup) (*ClassificationNode, error) {
+	c.mu.Lock()
+	cached, ok := c.classification[group]
+	c.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s/_apis/wit/classificationnodes/%s?$depth=%d&api-version=7.0",
+		c.BaseURL,
+		url.PathEscape(c.Organization),
+		url.PathEscape(c.Project),
+		group,
+		maxClassificationDepth)
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var nodeResp classificationNodeResponse
+	if err := c.do(req, &nodeResp); err != nil {
+		return nil, err
+	}
+
+	root := convertClassificationNode(nodeResp)
+	c.mu.Lock()
+	c.classification[group] = root
+	c.mu.Unlock()
+	return root, nil
+}
+
+// teamIterationsResponse represents the iterations of a team
+type teamIterationsResponse struct {
+	Count int `json:"count"`
+	Value []struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		Path       string `json:"path"`
+		Attributes struct {
+			StartDate  time.Time `json:"startDate"`
+			FinishDate time.Time `json:"finishDate"`
+		} `json:"attributes"`
+	} `json:"value"`
+}
+
+// GetCurrentIteration returns the iteration the team is currently working in
+func (c *AzureClient) GetCurrentIteration() (*ClassificationNode, error) {
+	team, err := c.team()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", c.teamURL(team, "work/teamsettings/iterations?$timeframe=current&api-version=7.0"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var iterationsResp teamIterationsResponse
+	if err := c.do(req, &iterationsResp); err != nil {
+		return nil, err
+	}
+	if len(iterationsResp.Value) == 0 {
+		return nil, &APIError{Kind: ErrorNotFound, Message: fmt.Sprintf("team %s has no current iteration", team)}
+	}
+
+	current := iterationsResp.Value[0]
+	return &ClassificationNode{
+		Identifier: current.ID,
+		Name:       current.Name,
+		Path:       current.Path,
+		StartDate:  current.Attributes.StartDate,
+		FinishDate: current.Attributes.FinishDate,
+	}, nil
+}
+
+// TeamArea is an area path whose work items belong to a team
+type TeamArea struct {
+	Path string
+	// IncludeChildren reports whether the work items of the sub-areas belong to the team too
+	IncludeChildren bool
+}
+
+// teamFieldValuesResponse represents the values of the team field owned by a team
+type teamFieldValuesResponse struct {
+	Field struct {
+		ReferenceName string `json:"referenceName"`
+	} `json:"field"`
+	Values []struct {
+		Value           string `json:"value"`
+		IncludeChildren bool   `json:"includeChildren"`
+	} `json:"values"`
+}
+
+// GetTeamAreas returns the area paths owned by the team
+func (c *AzureClient) GetTeamAreas() ([]TeamArea, error) {
+	team, err := c.team()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", c.teamURL(team, "work/teamsettings/teamfieldvalues?api-version=7.0"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var valuesResp teamFieldValuesResponse
+	if err := c.do(req, &valuesResp); err != nil {
+		return nil, err
+	}
+	// Projects can use another field than the area path to assign work items to teams
+	if valuesResp.Field.ReferenceName != FieldAreaPath {
+		return nil, fmt.Errorf("team %s is scoped by %s, only %s is supported", team, valuesResp.Field.ReferenceName, FieldAreaPath)
+	}
+
+	areas := make([]TeamArea, len(valuesResp.Values))
+	for i, value := range valuesResp.Values {
+		areas[i] = TeamArea{Path: value.Value, IncludeChildren: value.IncludeChildren}
+	}
+	return areas, nil
+}
+
+// resolveIteration replaces @CurrentIteration in the query parameters with the
+// path of the team's current iteration
+func resolveIteration(params QueryParams, current func() (*ClassificationNode, error)) (QueryParams, error) {
+	if params.IterationPath != string(MacroCurrentIteration) {
+		return params, nil
+	}
+	iteration, err := current()
+	if err != nil {
+		return params, fmt.Errorf("failed to resolve %s: %w", MacroCurrentIteration, err)
+	}
+	params.IterationPath = iteration.Path
+	return params, nil
+}
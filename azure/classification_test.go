@@ -0,0 +1,138 @@
+package azure
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestGetClassificationNodes checks that node paths are converted to field values
+// and that iteration dates are read
+func TestGetClassificationNodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/project/_apis/wit/classificationnodes/iterations" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{
+			"id": 1, "name": "project", "path": "\\project\\Iteration",
+			"children": [{
+				"id": 2, "name": "Sprint 1", "path": "\\project\\Iteration\\Release 1\\Sprint 1",
+				"attributes": {"startDate": "2024-03-04T00:00:00Z", "finishDate": "2024-03-15T00:00:00Z"}
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	root, err := client.GetClassificationNodes(Iterations)
+	if err != nil {
+		t.Fatalf("GetClassificationNodes failed: %v", err)
+	}
+	if root.Path != "project" {
+		t.Errorf("root path = %q, want project", root.Path)
+	}
+	sprint := root.Find(`project\Release 1\Sprint 1`)
+	if sprint == nil {
+		t.Fatalf("Sprint 1 not found in %+v", root.Children)
+	}
+	if !sprint.Contains(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)) || sprint.Contains(time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Sprint 1 runs from %v to %v", sprint.StartDate, sprint.FinishDate)
+	}
+}
+
+// TestQueryCurrentIteration checks that @CurrentIteration is resolved from the
+// team settings and matches work items under the iteration
+func TestQueryCurrentIteration(t *testing.T) {
+	var wiql string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/project/project Team/_apis/work/teamsettings/iterations":
+			if r.URL.Query().Get("$timeframe") != "current" {
+				t.Errorf("timeframe = %q, want current", r.URL.Query().Get("$timeframe"))
+			}
+			w.Write([]byte(`{"count": 1, "value": [{"name": "Sprint 2", "path": "project\\Sprint 2"}]}`))
+		case "/org/project/_apis/wit/wiql":
+			var query wiqlQuery
+			json.NewDecoder(r.Body).Decode(&query)
+			wiql = query.Query
+			w.Write([]byte(`{"workItems": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+	client.Team = "project Team"
+
+	if _, err := client.QueryWorkItems(QueryParams{IterationPath: string(MacroCurrentIteration)}); err != nil {
+		t.Fatalf("QueryWorkItems failed: %v", err)
+	}
+	want := `SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = 'project' AND [System.IterationPath] UNDER 'project\Sprint 2'`
+	if wiql != want {
+		t.Errorf("got %q, want %q", wiql, want)
+	}
+}
+
+// TestUnderPath checks matching of area and iteration paths
+func TestUnderPath(t *testing.T) {
+	tests := []struct {
+		path, parent string
+		want         bool
+	}{
+		{`Fazure\Web`, `Fazure\Web`, true},
+		{`Fazure\Web\Forms`, `fazure\web`, true},
+		{`Fazure\Website`, `Fazure\Web`, false},
+		{`Fazure`, `Fazure\Web`, false},
+	}
+	for _, tt := range tests {
+		if got := UnderPath(tt.path, tt.parent); got != tt.want {
+			t.Errorf("UnderPath(%q, %q) = %v, want %v", tt.path, tt.parent, got, tt.want)
+		}
+	}
+}
+
+// TestGetTeamAreas checks that the areas owned by the team are read from its team field
+func TestGetTeamAreas(t *testing.T) {
+	field := FieldAreaPath
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/project/project Team/_apis/work/teamsettings/teamfieldvalues" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{
+			"field": {"referenceName": "` + field + `"},
+			"defaultValue": "project\\Web",
+			"values": [
+				{"value": "project\\Web", "includeChildren": true},
+				{"value": "project", "includeChildren": false}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+	client.Team = "project Team"
+
+	areas, err := client.GetTeamAreas()
+	if err != nil {
+		t.Fatalf("GetTeamAreas failed: %v", err)
+	}
+	want := []TeamArea{{Path: `project\Web`, IncludeChildren: true}, {Path: "project"}}
+	if !slices.Equal(areas, want) {
+		t.Errorf("got %+v, want %+v", areas, want)
+	}
+
+	field = "Custom.Team"
+	if _, err := client.GetTeamAreas(); err == nil {
+		t.Error("expected an error for a team scoped by another field")
+	}
+}
@@ -5,36 +5,141 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBaseURL is the base URL of Azure DevOps Services
+const DefaultBaseURL = "https://dev.azure.com"
+
+const (
+	// maxBatchSize is the maximum number of work items the API returns per request
+	maxBatchSize = 200
+	// maxConcurrentBatches limits how many batch requests are in flight at once
+	maxConcurrentBatches = 4
 )
 
 // AzureClient represents an Azure DevOps API client
 type AzureClient struct {
+	BaseURL      string
 	Organization string
 	Project      string
+	Team         string // Team whose members and settings are used, the project's default team when empty
 	PAT          string
 	HTTPClient   *http.Client
+
+	mu             sync.Mutex
+	defaultTeam    string
+	workflows      map[WorkItemType]*Workflow
+	classification map[ClassificationGroup]*ClassificationNode
 }
 
 // NewClient creates a new Azure DevOps client
 func NewClient(organization, project, pat string) *AzureClient {
 	return &AzureClient{
-		Organization: organization,
-		Project:      project,
-		PAT:          pat,
-		HTTPClient:   &http.Client{},
+		BaseURL:        DefaultBaseURL,
+		Organization:   organization,
+		Project:        project,
+		PAT:            pat,
+		HTTPClient:     &http.Client{},
+		workflows:      map[WorkItemType]*Workflow{},
+		classification: map[ClassificationGroup]*ClassificationNode{},
+	}
+}
+
+// projectResponse represents a project and its default team
+type projectResponse struct {
+	DefaultTeam struct {
+		Name string `json:"name"`
+	} `json:"defaultTeam"`
+}
+
+// team returns the team of the client. The project's default team is looked up
+// when no team was chosen, and cached for the lifetime of the client.
+func (c *AzureClient) team() (string, error) {
+	if c.Team != "" {
+		return c.Team, nil
+	}
+	c.mu.Lock()
+	cached := c.defaultTeam
+	c.mu.Unlock()
+	if cached != "" {
+		return cached, nil
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/_apis/projects/%s?api-version=7.0",
+		c.BaseURL,
+		url.PathEscape(c.Organization),
+		url.PathEscape(c.Project))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var projectResp projectResponse
+	if err := c.do(req, &projectResp); err != nil {
+		return "", fmt.Errorf("failed to look up the default team of project %s, set AZURE_TEAM to choose a team: %w", c.Project, err)
 	}
+	if projectResp.DefaultTeam.Name == "" {
+		return "", fmt.Errorf("project %s has no default team, set AZURE_TEAM to choose a team", c.Project)
+	}
+
+	c.mu.Lock()
+	c.defaultTeam = projectResp.DefaultTeam.Name
+	c.mu.Unlock()
+	return projectResp.DefaultTeam.Name, nil
 }
 
 // QueryParams represents parameters for querying work items
 type QueryParams struct {
 	AssignedTo    string
-	State         string
+	States        []string
+	ExcludeStates []string
+	Types         []WorkItemType
+	ExcludeTypes  []WorkItemType
+	Tags          []string
+	ExcludeTags   []string
+	// IterationPath and AreaPath match work items under the given path.
+	// An IterationPath of @CurrentIteration is the current iteration of the team.
 	IterationPath string
 	AreaPath      string
+	// Areas matches work items in any of the areas owned by a team
+	Areas []TeamArea
+	// FinishedSince leaves out work items in a terminal state unless they were
+	// changed on or after the given day
+	FinishedSince time.Time
+	OrderBy       []SortKey
+	// Fields are the field reference names to fetch for each work item.
+	// The ID, revision and type are always fetched; all detail fields are fetched when empty.
+	Fields []string
+	// Relations fetches the links of each work item. All fields are fetched
+	// then, as the API cannot expand the relations of selected fields.
+	Relations bool
+}
+
+// SortField is a field query results can be ordered by
+type SortField string
+
+const (
+	SortByPriority        SortField = FieldPriority
+	SortByChangedDate     SortField = FieldChangedDate
+	SortByStackRank       SortField = FieldStackRank
+	SortByBacklogPriority SortField = FieldBacklogPriority
+	SortByID              SortField = FieldID
+)
+
+// SortKey orders query results by a single field
+type SortKey struct {
+	Field      SortField
+	Descending bool
 }
 
 // wiqlQuery represents the request body for WIQL queries
@@ -50,17 +155,75 @@ type wiqlResponse struct {
 	} `json:"workItems"`
 }
 
+// workItemResponse represents a single work item returned by the API
+type workItemResponse struct {
+	ID        int                `json:"id"`
+	Rev       int                `json:"rev"`
+	Fields    map[string]any     `json:"fields"`
+	Relations []relationResponse `json:"relations"`
+}
+
+// relationResponse represents a link of a work item returned by the API
+type relationResponse struct {
+	Rel        string         `json:"rel"`
+	URL        string         `json:"url"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+// workItemsBatchRequest represents the request body for the workitemsbatch endpoint
+type workItemsBatchRequest struct {
+	IDs         []int    `json:"ids"`
+	Fields      []string `json:"fields,omitempty"`
+	Expand      string   `json:"$expand,omitempty"`
+	ErrorPolicy string   `json:"errorPolicy"`
+}
+
 // workItemsResponse represents the response when fetching work item details
 type workItemsResponse struct {
-	Count int `json:"count"`
-	Value []struct {
-		ID     int               `json:"id"`
-		Fields map[string]any `json:"fields"`
-	} `json:"value"`
+	Count int                `json:"count"`
+	Value []workItemResponse `json:"value"`
+}
+
+// PatchOperation represents a single JSON Patch operation applied to a work item
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value,omitempty"`
+}
+
+// SetField returns a patch operation that sets a work item field to the given value
+func SetField(field string, value any) PatchOperation {
+	return PatchOperation{Op: "add", Path: "/fields/" + field, Value: value}
+}
+
+// RelationValue is the value of a patch operation that adds a link
+type RelationValue struct {
+	Rel        string         `json:"rel"`
+	URL        string         `json:"url"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// AddRelation returns a patch operation that links a work item to the resource at url
+func AddRelation(rel, url, comment string) PatchOperation {
+	value := RelationValue{Rel: rel, URL: url}
+	if comment != "" {
+		value.Attributes = map[string]any{"comment": comment}
+	}
+	return PatchOperation{Op: "add", Path: "/relations/-", Value: value}
+}
+
+// RemoveRelation returns a patch operation that removes the link at index of the work item's relations
+func RemoveRelation(index int) PatchOperation {
+	return PatchOperation{Op: "remove", Path: fmt.Sprintf("/relations/%d", index)}
 }
 
 // QueryWorkItems queries work items from Azure DevOps based on the provided parameters
 func (c *AzureClient) QueryWorkItems(params QueryParams) ([]WorkItem, error) {
+	params, err := resolveIteration(params, c.GetCurrentIteration)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build WIQL query
 	wiql := c.buildWIQL(params)
 
@@ -74,8 +237,12 @@ func (c *AzureClient) QueryWorkItems(params QueryParams) ([]WorkItem, error) {
 		return []WorkItem{}, nil
 	}
 
-	// Fetch full work item details
-	workItems, err := c.getWorkItemDetails(ids)
+	// Fetch the requested fields of each work item
+	fields := queryFields(params.Fields)
+	if params.Relations {
+		fields = nil
+	}
+	workItems, err := c.getWorkItemDetails(ids, fields, params.Relations)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get work item details: %w", err)
 	}
@@ -85,33 +252,76 @@ func (c *AzureClient) QueryWorkItems(params QueryParams) ([]WorkItem, error) {
 
 // buildWIQL constructs a WIQL query based on the provided parameters
 func (c *AzureClient) buildWIQL(params QueryParams) string {
-	conditions := []string{
-		fmt.Sprintf("[System.TeamProject] = '%s'", c.Project),
+	conditions := []Clause{
+		Equals(FieldTeamProject, c.Project),
 	}
 
 	if params.AssignedTo != "" {
-		conditions = append(conditions, fmt.Sprintf("[System.AssignedTo] = '%s'", params.AssignedTo))
+		conditions = append(conditions, Equals(FieldAssignedTo, params.AssignedTo))
+	}
+
+	if len(params.States) > 0 {
+		conditions = append(conditions, In(FieldState, params.States...))
+	}
+
+	if len(params.ExcludeStates) > 0 {
+		conditions = append(conditions, NotIn(FieldState, params.ExcludeStates...))
+	}
+
+	if len(params.Types) > 0 {
+		conditions = append(conditions, In(FieldWorkItemType, params.Types...))
+	}
+
+	if len(params.ExcludeTypes) > 0 {
+		conditions = append(conditions, NotIn(FieldWorkItemType, params.ExcludeTypes...))
+	}
+
+	for _, tag := range params.Tags {
+		conditions = append(conditions, Contains(FieldTags, tag))
 	}
 
-	if params.State != "" {
-		conditions = append(conditions, fmt.Sprintf("[System.State] = '%s'", params.State))
+	for _, tag := range params.ExcludeTags {
+		conditions = append(conditions, NotContains(FieldTags, tag))
 	}
 
 	if params.IterationPath != "" {
-		conditions = append(conditions, fmt.Sprintf("[System.IterationPath] = '%s'", params.IterationPath))
+		conditions = append(conditions, Under(FieldIterationPath, params.IterationPath))
 	}
 
 	if params.AreaPath != "" {
-		conditions = append(conditions, fmt.Sprintf("[System.AreaPath] = '%s'", params.AreaPath))
+		conditions = append(conditions, Under(FieldAreaPath, params.AreaPath))
 	}
 
-	whereClause := strings.Join(conditions, " AND ")
-	return fmt.Sprintf("SELECT [System.Id] FROM WorkItems WHERE %s", whereClause)
+	if len(params.Areas) > 0 {
+		var areas []Clause
+		for _, area := range params.Areas {
+			if area.IncludeChildren {
+				areas = append(areas, Under(FieldAreaPath, area.Path))
+			} else {
+				areas = append(areas, Equals(FieldAreaPath, area.Path))
+			}
+		}
+		conditions = append(conditions, Or(areas...))
+	}
+
+	if !params.FinishedSince.IsZero() {
+		conditions = append(conditions, Or(
+			NotIn(FieldState, TerminalStates...),
+			OnOrAfter(FieldChangedDate, params.FinishedSince),
+		))
+	}
+
+	query := Query{
+		Where:   And(conditions...),
+		OrderBy: params.OrderBy,
+	}
+	return query.String()
 }
 
 // executeWIQL executes a WIQL query and returns work item IDs
 func (c *AzureClient) executeWIQL(wiql string) ([]int, error) {
-	apiURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/wit/wiql?api-version=7.0",
+	apiURL := fmt.Sprintf("%s/%s/%s/_apis/wit/wiql?api-version=7.0",
+		c.BaseURL,
 		url.PathEscape(c.Organization),
 		url.PathEscape(c.Project))
 
@@ -128,20 +338,9 @@ func (c *AzureClient) executeWIQL(wiql string) ([]int, error) {
 
 	c.setHeaders(req)
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
 	var wiqlResp wiqlResponse
-	if err := json.NewDecoder(resp.Body).Decode(&wiqlResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.do(req, &wiqlResp); err != nil {
+		return nil, err
 	}
 
 	ids := make([]int, len(wiqlResp.WorkItems))
@@ -152,139 +351,374 @@ func (c *AzureClient) executeWIQL(wiql string) ([]int, error) {
 	return ids, nil
 }
 
-// getWorkItemDetails fetches full details for the given work item IDs
-func (c *AzureClient) getWorkItemDetails(ids []int) ([]WorkItem, error) {
+// detailFields are the fields fetched for every work item
+var detailFields = []string{
+	FieldID,
+	FieldRev,
+	FieldWorkItemType,
+	FieldTitle,
+	FieldAssignedTo,
+	FieldState,
+	FieldPriority,
+	FieldDescription,
+	FieldAcceptanceCriteria,
+	FieldCreatedBy,
+	FieldCreatedDate,
+	FieldChangedDate,
+	FieldTags,
+	FieldAreaPath,
+	FieldIterationPath,
+	FieldStoryPoints,
+	FieldOriginalEstimate,
+	FieldRemainingWork,
+	FieldCompletedWork,
+}
+
+// queryFields returns the fields to fetch for a query, adding the fields every
+// WorkItem needs to the requested ones
+func queryFields(fields []string) []string {
+	if len(fields) == 0 {
+		return detailFields
+	}
+
+	out := []string{FieldID, FieldRev, FieldWorkItemType}
+	for _, field := range fields {
+		if !slices.Contains(out, field) {
+			out = append(out, field)
+		}
+	}
+	return out
+}
+
+// getWorkItemDetails fetches the given fields of the work items with the given IDs,
+// or all of their fields when fields is empty, along with their links if relations
+// is set. IDs are fetched in batches with bounded concurrency and the results are
+// returned in the order of ids; items that no longer exist are left out.
+func (c *AzureClient) getWorkItemDetails(ids []int, fields []string, relations bool) ([]WorkItem, error) {
 	if len(ids) == 0 {
 		return []WorkItem{}, nil
 	}
 
-	// Convert IDs to comma-separated string
-	idStrs := make([]string, len(ids))
-	for i, id := range ids {
-		idStrs[i] = fmt.Sprintf("%d", id)
+	batches := chunkIDs(ids, maxBatchSize)
+	results := make([][]WorkItem, len(batches))
+	errs := make([]error, len(batches))
+
+	sem := make(chan struct{}, maxConcurrentBatches)
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = c.getWorkItemBatch(batch, fields, relations)
+		})
 	}
-	idsParam := strings.Join(idStrs, ",")
+	wg.Wait()
 
-	// Build URL with all required fields
-	fields := []string{
-		"System.Id",
-		"System.WorkItemType",
-		"System.Title",
-		"System.AssignedTo",
-		"System.State",
-		"Microsoft.VSTS.Common.Priority",
-		"System.Description",
-		"Microsoft.VSTS.Common.AcceptanceCriteria",
-		"System.CreatedBy",
-		"System.CreatedDate",
-		"System.Tags",
-		"System.AreaPath",
-		"System.IterationPath",
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	apiURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/wit/workitems?ids=%s&fields=%s&api-version=7.0",
-		url.PathEscape(c.Organization),
-		url.PathEscape(c.Project),
-		idsParam,
-		strings.Join(fields, ","))
+	byID := make(map[int]WorkItem, len(ids))
+	for _, batch := range results {
+		for _, wi := range batch {
+			byID[wi.ID] = wi
+		}
+	}
 
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	workItems := make([]WorkItem, 0, len(ids))
+	for _, id := range ids {
+		if wi, ok := byID[id]; ok {
+			workItems = append(workItems, wi)
+		}
 	}
 
-	c.setHeaders(req)
+	return workItems, nil
+}
 
-	resp, err := c.HTTPClient.Do(req)
+// getWorkItemBatch fetches up to maxBatchSize work items with a single request
+func (c *AzureClient) getWorkItemBatch(ids []int, fields []string, relations bool) ([]WorkItem, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s/_apis/wit/workitemsbatch?api-version=7.0",
+		c.BaseURL,
+		url.PathEscape(c.Organization),
+		url.PathEscape(c.Project))
+
+	request := workItemsBatchRequest{
+		IDs:         ids,
+		Fields:      fields,
+		ErrorPolicy: "omit",
+	}
+	if relations {
+		request.Expand = "relations"
+	}
+	body, err := json.Marshal(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	c.setHeaders(req)
+
 	var wiResp workItemsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&wiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.do(req, &wiResp); err != nil {
+		return nil, err
 	}
 
-	// Convert to WorkItem structs
+	// Convert to WorkItem structs, skipping items omitted by the error policy
 	workItems := make([]WorkItem, 0, len(wiResp.Value))
 	for _, item := range wiResp.Value {
-		wi := c.convertToWorkItem(item.ID, item.Fields)
+		if item.ID == 0 {
+			continue
+		}
+		wi := c.convertToWorkItem(item)
 		workItems = append(workItems, wi)
 	}
 
 	return workItems, nil
 }
 
+// chunkIDs splits ids into consecutive chunks of at most size IDs
+func chunkIDs(ids []int, size int) [][]int {
+	var chunks [][]int
+	for len(ids) > size {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+	return append(chunks, ids)
+}
+
+// GetWorkItem fetches a single work item by ID with all of its fields and links
+func (c *AzureClient) GetWorkItem(id int) (*WorkItem, error) {
+	workItems, err := c.getWorkItemDetails([]int{id}, nil, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work item %d: %w", id, err)
+	}
+	if len(workItems) == 0 {
+		return nil, &APIError{Kind: ErrorNotFound, Message: fmt.Sprintf("work item %d not found", id)}
+	}
+	return &workItems[0], nil
+}
+
+// GetWorkItems fetches the ID, type, title and state of the work items with the
+// given IDs, e.g. to describe the targets of links
+func (c *AzureClient) GetWorkItems(ids []int) ([]WorkItem, error) {
+	fields := []string{FieldID, FieldRev, FieldWorkItemType, FieldTitle, FieldState}
+	workItems, err := c.getWorkItemDetails(ids, fields, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work items: %w", err)
+	}
+	return workItems, nil
+}
+
+// WorkItemURL returns the API URL of a work item, which identifies it as the target of links
+func (c *AzureClient) WorkItemURL(id int) string {
+	return fmt.Sprintf("%s/%s/_apis/wit/workItems/%d",
+		c.BaseURL,
+		url.PathEscape(c.Organization),
+		id)
+}
+
+// CreateWorkItem creates a work item of the given type from patch operations
+// setting its fields and links, and returns the new item
+func (c *AzureClient) CreateWorkItem(itemType WorkItemType, ops []PatchOperation) (*WorkItem, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s/_apis/wit/workitems/$%s?api-version=7.0",
+		c.BaseURL,
+		url.PathEscape(c.Organization),
+		url.PathEscape(c.Project),
+		url.PathEscape(string(itemType)))
+
+	body, err := json.Marshal(ops)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json-patch+json")
+
+	var item workItemResponse
+	if err := c.do(req, &item); err != nil {
+		return nil, err
+	}
+
+	wi := c.convertToWorkItem(item)
+	return &wi, nil
+}
+
+// UpdateWorkItem applies JSON Patch operations to a work item and returns the updated item.
+// The update is rejected with ErrRevisionConflict if the work item is no longer at revision rev.
+func (c *AzureClient) UpdateWorkItem(id, rev int, ops []PatchOperation) (*WorkItem, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s/_apis/wit/workitems/%d?api-version=7.0",
+		c.BaseURL,
+		url.PathEscape(c.Organization),
+		url.PathEscape(c.Project),
+		id)
+
+	ops = append([]PatchOperation{{Op: "test", Path: "/rev", Value: rev}}, ops...)
+	body, err := json.Marshal(ops)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	req, err := http.NewRequest("PATCH", apiURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json-patch+json")
+
+	var item workItemResponse
+	if err := c.do(req, &item); err != nil {
+		return nil, err
+	}
+
+	wi := c.convertToWorkItem(item)
+	return &wi, nil
+}
+
 // convertToWorkItem converts Azure DevOps API response fields to a WorkItem struct
-func (c *AzureClient) convertToWorkItem(id int, fields map[string]any) WorkItem {
+func (c *AzureClient) convertToWorkItem(item workItemResponse) WorkItem {
+	fields := item.Fields
 	wi := WorkItem{
-		ID: id,
+		ID:     item.ID,
+		Rev:    item.Rev,
+		Fields: fields,
+	}
+
+	if v, ok := fields[FieldRev].(float64); ok && wi.Rev == 0 {
+		wi.Rev = int(v)
 	}
 
-	if v, ok := fields["System.WorkItemType"].(string); ok {
+	if v, ok := fields[FieldWorkItemType].(string); ok {
 		wi.Type = WorkItemType(v)
 	}
 
-	if v, ok := fields["System.Title"].(string); ok {
+	if v, ok := fields[FieldTitle].(string); ok {
 		wi.Title = v
 	}
 
-	if v, ok := fields["System.AssignedTo"].(map[string]any); ok {
-		if name, ok := v["displayName"].(string); ok {
-			wi.AssignedTo = name
-		}
+	if identity, ok := identityFromField(fields[FieldAssignedTo]); ok {
+		wi.AssignedTo = identity.DisplayName
+		wi.Assignee = identity
 	}
 
-	if v, ok := fields["System.State"].(string); ok {
+	if v, ok := fields[FieldState].(string); ok {
 		wi.State = v
 	}
 
-	if v, ok := fields["Microsoft.VSTS.Common.Priority"].(float64); ok {
+	if v, ok := fields[FieldPriority].(float64); ok {
 		wi.Priority = int(v)
 	}
 
-	if v, ok := fields["System.Description"].(string); ok {
+	if v, ok := fields[FieldDescription].(string); ok {
 		wi.Description = v
 	}
 
-	if v, ok := fields["Microsoft.VSTS.Common.AcceptanceCriteria"].(string); ok {
+	if v, ok := fields[FieldAcceptanceCriteria].(string); ok {
 		wi.AcceptanceCriteria = v
 	}
 
-	if v, ok := fields["System.CreatedBy"].(map[string]any); ok {
+	if v, ok := fields[FieldCreatedBy].(map[string]any); ok {
 		if name, ok := v["displayName"].(string); ok {
 			wi.CreatedBy = name
 		}
 	}
 
-	if v, ok := fields["System.CreatedDate"].(string); ok {
+	if v, ok := fields[FieldCreatedDate].(string); ok {
 		wi.CreatedDate = v
 	}
 
-	if v, ok := fields["System.Tags"].(string); ok {
+	if v, ok := fields[FieldChangedDate].(string); ok {
+		wi.ChangedDate = v
+	}
+
+	if v, ok := fields[FieldTags].(string); ok {
 		if v != "" {
 			tags := strings.Split(v, "; ")
 			wi.Tags = tags
 		}
 	}
 
-	if v, ok := fields["System.AreaPath"].(string); ok {
+	if v, ok := fields[FieldAreaPath].(string); ok {
 		wi.AreaPath = v
 	}
 
-	if v, ok := fields["System.IterationPath"].(string); ok {
+	if v, ok := fields[FieldIterationPath].(string); ok {
 		wi.Iteration = v
 	}
 
+	wi.StoryPoints, _ = fields[FieldStoryPoints].(float64)
+	wi.OriginalEstimate, _ = fields[FieldOriginalEstimate].(float64)
+	wi.RemainingWork, _ = fields[FieldRemainingWork].(float64)
+	wi.CompletedWork, _ = fields[FieldCompletedWork].(float64)
+
+	for _, rel := range item.Relations {
+		wi.Relations = append(wi.Relations, convertToRelation(rel))
+	}
+
 	return wi
 }
 
+// convertToRelation converts a link returned by the API to a Relation
+func convertToRelation(rel relationResponse) Relation {
+	r := Relation{
+		Rel: rel.Rel,
+		URL: rel.URL,
+	}
+	if v, ok := rel.Attributes["name"].(string); ok {
+		r.Name = v
+	}
+	if v, ok := rel.Attributes["comment"].(string); ok {
+		r.Comment = v
+	}
+
+	r.TargetID = LinkTargetID(rel.URL)
+	return r
+}
+
+// LinkTargetID returns the ID of the work item a link URL points to, or 0 if
+// it does not point to a work item. Work item URLs end in _apis/wit/workItems/{id}.
+func LinkTargetID(url string) int {
+	const prefix = "/_apis/wit/workitems/"
+	i := strings.LastIndex(strings.ToLower(url), prefix)
+	if i < 0 {
+		return 0
+	}
+	id, err := strconv.Atoi(url[i+len(prefix):])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// do executes a request and decodes a successful JSON response into out
+func (c *AzureClient) do(req *http.Request, out any) error {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return networkError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return readError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
 // setHeaders sets common headers for Azure DevOps API requests
 func (c *AzureClient) setHeaders(req *http.Request) {
 	auth := base64.StdEncoding.EncodeToString([]byte(":" + c.PAT))
@@ -1,8 +1,16 @@
 package azure
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -28,7 +36,7 @@ func TestQueryActiveWorkItems(t *testing.T) {
 	// Query active work items for the user
 	params := QueryParams{
 		AssignedTo: user,
-		State:      "Active",
+		States:     []string{"Active"},
 	}
 
 	fmt.Printf("\n=== Querying Active Work Items ===\n")
@@ -47,6 +55,347 @@ func TestQueryActiveWorkItems(t *testing.T) {
 	}
 }
 
+// TestGetWorkItemDetailsBatches checks that details are fetched in batches of at most
+// 200 IDs and returned in the order of the requested IDs
+func TestGetWorkItemDetailsBatches(t *testing.T) {
+	var mu sync.Mutex
+	var batchSizes []int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/org/project/_apis/wit/workitemsbatch" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var body workItemsBatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		batchSizes = append(batchSizes, len(body.IDs))
+		mu.Unlock()
+
+		// Answer in reverse order and leave out deleted items
+		var resp workItemsResponse
+		for _, id := range slices.Backward(body.IDs) {
+			if id%100 == 0 {
+				continue
+			}
+			resp.Value = append(resp.Value, workItemResponse{
+				ID:     id,
+				Rev:    1,
+				Fields: map[string]any{FieldTitle: fmt.Sprintf("Item %d", id)},
+			})
+		}
+		resp.Count = len(resp.Value)
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	var ids, want []int
+	for i := 450; i > 0; i-- {
+		ids = append(ids, i*3)
+		if (i*3)%100 != 0 {
+			want = append(want, i*3)
+		}
+	}
+
+	workItems, err := client.getWorkItemDetails(ids, detailFields, false)
+	if err != nil {
+		t.Fatalf("getWorkItemDetails failed: %v", err)
+	}
+
+	slices.Sort(batchSizes)
+	if !slices.Equal(batchSizes, []int{50, 200, 200}) {
+		t.Errorf("batch sizes = %v, want [50 200 200]", batchSizes)
+	}
+
+	got := make([]int, len(workItems))
+	for i, wi := range workItems {
+		got[i] = wi.ID
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("work items are not in the requested order")
+	}
+}
+
+// TestQueryWorkItemsFields checks that queries fetch exactly the requested fields
+// and that arbitrary fields can be read from the result
+func TestQueryWorkItemsFields(t *testing.T) {
+	var requested []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/project/_apis/wit/wiql":
+			json.NewEncoder(w).Encode(map[string]any{"workItems": []map[string]any{{"id": 7}}})
+		case "/org/project/_apis/wit/workitemsbatch":
+			var body workItemsBatchRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			requested = body.Fields
+			json.NewEncoder(w).Encode(workItemsResponse{Count: 1, Value: []workItemResponse{{
+				ID:  7,
+				Rev: 3,
+				Fields: map[string]any{
+					FieldWorkItemType: "User Story",
+					FieldStoryPoints:  5.0,
+					FieldTags:         "api; backend",
+				},
+			}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	workItems, err := client.QueryWorkItems(QueryParams{Fields: []string{FieldStoryPoints, FieldTags, FieldID}})
+	if err != nil {
+		t.Fatalf("QueryWorkItems failed: %v", err)
+	}
+
+	want := []string{FieldID, FieldRev, FieldWorkItemType, FieldStoryPoints, FieldTags}
+	if !slices.Equal(requested, want) {
+		t.Errorf("requested fields = %v, want %v", requested, want)
+	}
+	if len(workItems) != 1 {
+		t.Fatalf("got %d work items, want 1", len(workItems))
+	}
+	if got := workItems[0].Field(FieldStoryPoints); got != "5" {
+		t.Errorf("story points = %q, want %q", got, "5")
+	}
+	if got := workItems[0].Field(FieldTags); got != "api; backend" {
+		t.Errorf("tags = %q, want %q", got, "api; backend")
+	}
+}
+
+// TestConvertRelations checks that links to work items are resolved to their IDs
+func TestConvertRelations(t *testing.T) {
+	client := NewClient("org", "project", "pat")
+	wi := client.convertToWorkItem(workItemResponse{
+		ID: 2,
+		Relations: []relationResponse{
+			{Rel: RelParent, URL: "https://dev.azure.com/org/_apis/wit/workItems/1"},
+			{Rel: RelChild, URL: "https://dev.azure.com/org/_apis/wit/workItems/3"},
+			{Rel: RelChild, URL: "https://dev.azure.com/org/_apis/wit/workitems/4"},
+			{Rel: "Hyperlink", URL: "https://example.com", Attributes: map[string]any{"comment": "Spec"}},
+		},
+	})
+
+	if got := wi.ParentID(); got != 1 {
+		t.Errorf("ParentID() = %d, want 1", got)
+	}
+	if got := wi.ChildIDs(); !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("ChildIDs() = %v, want [3 4]", got)
+	}
+	if rel := wi.Relations[3]; rel.TargetID != 0 || rel.Comment != "Spec" {
+		t.Errorf("hyperlink = %+v, want no target and comment Spec", rel)
+	}
+}
+
+// TestRelationPatchOperations checks the JSON Patch documents that add and remove links
+func TestRelationPatchOperations(t *testing.T) {
+	tests := []struct {
+		op   PatchOperation
+		want string
+	}{
+		{
+			AddRelation(RelRelated, "https://dev.azure.com/org/_apis/wit/workItems/7", ""),
+			`{"op":"add","path":"/relations/-","value":{"rel":"System.LinkTypes.Related","url":"https://dev.azure.com/org/_apis/wit/workItems/7"}}`,
+		},
+		{
+			AddRelation(RelHyperlink, "https://example.com", "Spec"),
+			`{"op":"add","path":"/relations/-","value":{"rel":"Hyperlink","url":"https://example.com","attributes":{"comment":"Spec"}}}`,
+		},
+		{
+			RemoveRelation(2),
+			`{"op":"remove","path":"/relations/2"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.op)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("got %s, want %s", got, tt.want)
+		}
+	}
+
+	client := NewClient("my org", "project", "pat")
+	if got, want := client.WorkItemURL(7), "https://dev.azure.com/my%20org/_apis/wit/workItems/7"; got != want {
+		t.Errorf("WorkItemURL = %s, want %s", got, want)
+	}
+	if got := LinkTargetID(client.WorkItemURL(7)); got != 7 {
+		t.Errorf("LinkTargetID = %d, want 7", got)
+	}
+}
+
+// TestCreateWorkItem checks that work items are created with a JSON Patch document
+// posted to the endpoint of their type
+func TestCreateWorkItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/org/project/_apis/wit/workitems/$User Story" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json-patch+json" {
+			http.Error(w, "unexpected content type "+got, http.StatusUnsupportedMediaType)
+			return
+		}
+
+		var ops []PatchOperation
+		if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(workItemResponse{
+			ID:  42,
+			Rev: 1,
+			Fields: map[string]any{
+				FieldWorkItemType: "User Story",
+				FieldTitle:        ops[0].Value,
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	wi, err := client.CreateWorkItem(UserStory, []PatchOperation{SetField(FieldTitle, "Export to CSV")})
+	if err != nil {
+		t.Fatalf("CreateWorkItem failed: %v", err)
+	}
+	if wi.ID != 42 || wi.Type != UserStory || wi.Title != "Export to CSV" {
+		t.Errorf("created %+v, want User Story #42 \"Export to CSV\"", wi)
+	}
+}
+
+// TestUpdateWorkItem checks that updates are sent as a JSON Patch document
+func TestUpdateWorkItem(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/org/project/_apis/wit/workitems/42" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json-patch+json" {
+			http.Error(w, "unexpected content type "+got, http.StatusUnsupportedMediaType)
+			return
+		}
+
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		json.NewEncoder(w).Encode(workItemResponse{
+			ID:  42,
+			Rev: 4,
+			Fields: map[string]any{
+				FieldWorkItemType: "Bug",
+				FieldState:        "Active",
+				FieldPriority:     2,
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	wi, err := client.UpdateWorkItem(42, 3, []PatchOperation{
+		SetField(FieldState, "Active"),
+		SetField(FieldPriority, 2),
+		RemoveRelation(1),
+	})
+	if err != nil {
+		t.Fatalf("UpdateWorkItem failed: %v", err)
+	}
+	want := `[{"op":"test","path":"/rev","value":3},` +
+		`{"op":"add","path":"/fields/System.State","value":"Active"},` +
+		`{"op":"add","path":"/fields/Microsoft.VSTS.Common.Priority","value":2},` +
+		`{"op":"remove","path":"/relations/1"}]`
+	if body != want {
+		t.Errorf("sent %s, want %s", body, want)
+	}
+	if wi.Rev != 4 || wi.State != "Active" || wi.Priority != 2 {
+		t.Errorf("updated %+v, want rev 4, Active, priority 2", wi)
+	}
+}
+
+// TestUpdateWorkItemConflict checks that updates are conditional on the revision they
+// were made to, and that a rejected condition is reported as ErrRevisionConflict
+func TestUpdateWorkItemConflict(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ops []PatchOperation
+		if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(ops) == 0 || ops[0].Op != "test" || ops[0].Path != "/rev" || ops[0].Value != float64(7) {
+			t.Errorf("first op = %+v, want {test /rev 7}", ops)
+		}
+		w.WriteHeader(http.StatusPreconditionFailed)
+		w.Write([]byte(`{"message": "TF26071: This work item has been changed by someone else since you opened it."}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	_, err := client.UpdateWorkItem(42, 7, []PatchOperation{SetField(FieldTitle, "Export to CSV")})
+	if !errors.Is(err, ErrRevisionConflict) {
+		t.Errorf("got error %v, want ErrRevisionConflict", err)
+	}
+}
+
+// TestDefaultTeam checks that the default team is looked up once, and that a
+// project without one asks for AZURE_TEAM
+func TestDefaultTeam(t *testing.T) {
+	var lookups int
+	defaultTeam := `{"name": "Web Team"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/_apis/projects/project":
+			lookups++
+			w.Write([]byte(`{"id": "1", "name": "project", "defaultTeam": ` + defaultTeam + `}`))
+		case "/org/project/Web Team/_apis/work/boards":
+			w.Write([]byte(`{"count": 0, "value": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+	for range 2 {
+		if _, err := client.GetBoards(); err != nil {
+			t.Fatalf("GetBoards failed: %v", err)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("default team looked up %d times, want once", lookups)
+	}
+
+	defaultTeam = "null"
+	client = NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+	_, err := client.GetBoards()
+	if err == nil || !strings.Contains(err.Error(), "AZURE_TEAM") {
+		t.Errorf("got error %v, want one asking for AZURE_TEAM", err)
+	}
+}
+
 // printWorkItem prints a work item in a readable format
 func printWorkItem(wi WorkItem) {
 	fmt.Printf("┌─ Work Item #%d ─────────────────────────────────────\n", wi.ID)
@@ -71,4 +420,3 @@ func printWorkItem(wi WorkItem) {
 	}
 	fmt.Printf("└────────────────────────────────────────────────────────\n\n")
 }
-
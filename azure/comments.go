@@ -0,0 +1,136 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fazure/markup"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// commentsAPIVersion is the API version of the work item Comments API
+const commentsAPIVersion = "7.0-preview.3"
+
+// commentResponse represents a single comment returned by the Comments API
+type commentResponse struct {
+	ID        int    `json:"id"`
+	Text      string `json:"text"`
+	CreatedBy struct {
+		DisplayName string `json:"displayName"`
+	} `json:"createdBy"`
+	CreatedDate string `json:"createdDate"`
+}
+
+// commentsResponse represents a page of comments returned by the Comments API
+type commentsResponse struct {
+	TotalCount        int               `json:"totalCount"`
+	Count             int               `json:"count"`
+	Comments          []commentResponse `json:"comments"`
+	ContinuationToken string            `json:"continuationToken"`
+}
+
+// addCommentRequest represents the request body for posting a comment
+type addCommentRequest struct {
+	Text string `json:"text"`
+}
+
+// GetComments fetches the full discussion of a work item, oldest first
+func (c *AzureClient) GetComments(id int) ([]Comment, error) {
+	comments := []Comment{}
+	token := ""
+
+	for {
+		query := url.Values{}
+		query.Set("api-version", commentsAPIVersion)
+		query.Set("order", "asc")
+		if token != "" {
+			query.Set("continuationToken", token)
+		}
+
+		apiURL := fmt.Sprintf("%s/%s/%s/_apis/wit/workItems/%d/comments?%s",
+			c.BaseURL,
+			url.PathEscape(c.Organization),
+			url.PathEscape(c.Project),
+			id,
+			query.Encode())
+
+		page, err := c.getCommentsPage(apiURL)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, comment := range page.Comments {
+			comments = append(comments, convertToComment(comment))
+		}
+
+		if page.ContinuationToken == "" || len(page.Comments) == 0 {
+			return comments, nil
+		}
+		token = page.ContinuationToken
+	}
+}
+
+// getCommentsPage fetches a single page of comments
+func (c *AzureClient) getCommentsPage(apiURL string) (*commentsResponse, error) {
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var page commentsResponse
+	if err := c.do(req, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// AddComment posts a new comment to the discussion of a work item. Comments are
+// HTML, so the plain text is converted to keep its line breaks.
+func (c *AzureClient) AddComment(id int, text string) (*Comment, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s/_apis/wit/workItems/%d/comments?api-version=%s",
+		c.BaseURL,
+		url.PathEscape(c.Organization),
+		url.PathEscape(c.Project),
+		id,
+		commentsAPIVersion)
+
+	body, err := json.Marshal(addCommentRequest{Text: markup.FromPlain(text)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal comment: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var created commentResponse
+	if err := c.do(req, &created); err != nil {
+		return nil, err
+	}
+
+	comment := convertToComment(created)
+	return &comment, nil
+}
+
+// convertToComment converts a Comments API response to a Comment struct
+func convertToComment(comment commentResponse) Comment {
+	date := comment.CreatedDate
+	if t, err := time.Parse(time.RFC3339, comment.CreatedDate); err == nil {
+		date = t.Local().Format("2006-01-02 15:04")
+	}
+
+	return Comment{
+		ID:      comment.ID,
+		Author:  comment.CreatedBy.DisplayName,
+		Date:    date,
+		Content: comment.Text,
+	}
+}
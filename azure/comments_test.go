@@ -0,0 +1,112 @@
+package azure
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetComments checks that the pages of a discussion are followed through their
+// continuation tokens
+func TestGetComments(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/org/project/_apis/wit/workItems/42/comments" {
+			http.NotFound(w, r)
+			return
+		}
+		requests++
+		if got := r.URL.Query().Get("order"); got != "asc" {
+			t.Errorf("order = %q, want asc", got)
+		}
+
+		switch token := r.URL.Query().Get("continuationToken"); token {
+		case "":
+			w.Write([]byte(`{
+				"totalCount": 3, "count": 2, "continuationToken": "page2",
+				"comments": [
+					{"id": 1, "text": "First", "createdBy": {"displayName": "Jane Smith"}, "createdDate": "2024-03-04T09:30:00Z"},
+					{"id": 2, "text": "Second", "createdBy": {"displayName": "John Doe"}, "createdDate": "2024-03-05T10:00:00Z"}
+				]
+			}`))
+		case "page2":
+			w.Write([]byte(`{
+				"totalCount": 3, "count": 1,
+				"comments": [
+					{"id": 3, "text": "Third", "createdBy": {"displayName": "Jane Smith"}, "createdDate": "2024-03-06T11:15:00Z"}
+				]
+			}`))
+		default:
+			t.Errorf("unexpected continuation token %q", token)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	comments, err := client.GetComments(42)
+	if err != nil {
+		t.Fatalf("GetComments failed: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("fetched %d pages, want 2", requests)
+	}
+	if len(comments) != 3 {
+		t.Fatalf("got %d comments, want 3", len(comments))
+	}
+	for i, want := range []string{"First", "Second", "Third"} {
+		if comments[i].Content != want {
+			t.Errorf("comment %d = %q, want %q", i, comments[i].Content, want)
+		}
+	}
+	if comments[1].ID != 2 || comments[1].Author != "John Doe" {
+		t.Errorf("second comment = %+v, want #2 by John Doe", comments[1])
+	}
+}
+
+// TestAddComment checks that a comment is posted as HTML keeping its line breaks, and
+// that the created comment is read
+func TestAddComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/org/project/_apis/wit/workItems/42/comments" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("api-version"); got != commentsAPIVersion {
+			t.Errorf("api-version = %q, want %q", got, commentsAPIVersion)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", got)
+		}
+
+		var comment addCommentRequest
+		if err := json.NewDecoder(r.Body).Decode(&comment); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if want := "<p>Looks good<br>Ship it</p>"; comment.Text != want {
+			t.Errorf("posted %q, want %q", comment.Text, want)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":          7,
+			"text":        comment.Text,
+			"createdBy":   map[string]string{"displayName": "Jane Smith"},
+			"createdDate": "2024-03-04T09:30:00Z",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	comment, err := client.AddComment(42, "Looks good\nShip it")
+	if err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if comment.ID != 7 || comment.Author != "Jane Smith" || comment.Content != "<p>Looks good<br>Ship it</p>" {
+		t.Errorf("created %+v, want #7 by Jane Smith", comment)
+	}
+}
@@ -0,0 +1,142 @@
+package azure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrRevisionConflict is returned when a work item was changed by someone else
+// after the revision an update was based on
+var ErrRevisionConflict = errors.New("work item has been modified since it was loaded")
+
+// ErrorKind categorizes why an Azure DevOps request failed
+type ErrorKind int
+
+const (
+	ErrorUnknown ErrorKind = iota
+	ErrorAuth
+	ErrorNotFound
+	ErrorThrottled
+	ErrorServer
+	ErrorNetwork
+	ErrorConflict
+	ErrorValidation
+)
+
+// String returns a short description of the error kind
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorAuth:
+		return "authentication failed"
+	case ErrorNotFound:
+		return "not found"
+	case ErrorThrottled:
+		return "request throttled"
+	case ErrorServer:
+		return "server error"
+	case ErrorNetwork:
+		return "network error"
+	case ErrorConflict:
+		return "conflict"
+	case ErrorValidation:
+		return "validation failed"
+	default:
+		return "request failed"
+	}
+}
+
+// APIError describes a failed Azure DevOps request
+type APIError struct {
+	Kind       ErrorKind
+	StatusCode int
+	Message    string
+	TypeKey    string
+	RetryAfter time.Duration
+	Err        error
+}
+
+// errorResponse represents the error body returned by Azure DevOps
+type errorResponse struct {
+	Message string `json:"message"`
+	TypeKey string `json:"typeKey"`
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Message != "" && e.StatusCode != 0:
+		return fmt.Sprintf("%s (status %d): %s", e.Kind, e.StatusCode, e.Message)
+	case e.Message != "":
+		return fmt.Sprintf("%s: %s", e.Kind, e.Message)
+	case e.StatusCode != 0:
+		return fmt.Sprintf("%s (status %d)", e.Kind, e.StatusCode)
+	default:
+		return e.Kind.String()
+	}
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports conflicts as ErrRevisionConflict so callers can detect them with errors.Is
+func (e *APIError) Is(target error) bool {
+	return target == ErrRevisionConflict && e.Kind == ErrorConflict
+}
+
+// readError builds an APIError from an unsuccessful response, decoding the
+// message reported by Azure DevOps when the body contains one
+func readError(resp *http.Response) error {
+	apiErr := &APIError{
+		Kind:       errorKind(resp.StatusCode),
+		StatusCode: resp.StatusCode,
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	var errResp errorResponse
+	if err := json.Unmarshal(bodyBytes, &errResp); err == nil && errResp.Message != "" {
+		apiErr.Message = errResp.Message
+		apiErr.TypeKey = errResp.TypeKey
+	} else if apiErr.Kind != ErrorAuth {
+		// Authentication failures come back as an HTML sign-in page which is not worth showing
+		apiErr.Message = string(bodyBytes)
+	}
+
+	return apiErr
+}
+
+// errorKind maps an HTTP status code to an error kind
+func errorKind(status int) ErrorKind {
+	switch {
+	case status == http.StatusNonAuthoritativeInfo,
+		status == http.StatusUnauthorized,
+		status == http.StatusForbidden:
+		// Azure DevOps answers requests with an invalid PAT with a 203 sign-in page
+		return ErrorAuth
+	case status == http.StatusNotFound:
+		return ErrorNotFound
+	case status == http.StatusTooManyRequests:
+		return ErrorThrottled
+	case status == http.StatusConflict, status == http.StatusPreconditionFailed:
+		return ErrorConflict
+	case status == http.StatusBadRequest:
+		return ErrorValidation
+	case status >= 500:
+		return ErrorServer
+	default:
+		return ErrorUnknown
+	}
+}
+
+// networkError wraps a transport failure in an APIError
+func networkError(err error) error {
+	return &APIError{Kind: ErrorNetwork, Message: err.Error(), Err: err}
+}
@@ -0,0 +1,64 @@
+package azure
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestReadError checks that failed responses are categorized and decoded
+func TestReadError(t *testing.T) {
+	tests := []struct {
+		status  int
+		body    string
+		kind    ErrorKind
+		message string
+	}{
+		{http.StatusNonAuthoritativeInfo, "<html>Sign in</html>", ErrorAuth, ""},
+		{http.StatusUnauthorized, "", ErrorAuth, ""},
+		{http.StatusNotFound, `{"message":"TF200016: The project does not exist.","typeKey":"ProjectDoesNotExistException"}`, ErrorNotFound, "TF200016: The project does not exist."},
+		{http.StatusTooManyRequests, "", ErrorThrottled, ""},
+		{http.StatusBadRequest, `{"message":"TF401320: Rule Error for field Title."}`, ErrorValidation, "TF401320: Rule Error for field Title."},
+		{http.StatusPreconditionFailed, `{"message":"TF26071: This work item has been changed by someone else since you opened it."}`, ErrorConflict, "TF26071: This work item has been changed by someone else since you opened it."},
+		{http.StatusServiceUnavailable, "unavailable", ErrorServer, "unavailable"},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{
+			StatusCode: tt.status,
+			Header:     http.Header{"Retry-After": []string{"30"}},
+			Body:       io.NopCloser(strings.NewReader(tt.body)),
+		}
+
+		var apiErr *APIError
+		if !errors.As(readError(resp), &apiErr) {
+			t.Fatalf("status %d: expected *APIError", tt.status)
+		}
+		if apiErr.Kind != tt.kind {
+			t.Errorf("status %d: kind = %v, want %v", tt.status, apiErr.Kind, tt.kind)
+		}
+		if apiErr.Message != tt.message {
+			t.Errorf("status %d: message = %q, want %q", tt.status, apiErr.Message, tt.message)
+		}
+		if apiErr.RetryAfter != 30*time.Second {
+			t.Errorf("status %d: retry after = %v, want 30s", tt.status, apiErr.RetryAfter)
+		}
+	}
+}
+
+// TestConflictIsRevisionConflict checks that wrapped conflicts match ErrRevisionConflict
+func TestConflictIsRevisionConflict(t *testing.T) {
+	err := fmt.Errorf("failed to update work item: %w", &APIError{Kind: ErrorConflict, StatusCode: http.StatusPreconditionFailed})
+	if !errors.Is(err, ErrRevisionConflict) {
+		t.Errorf("expected conflict to match ErrRevisionConflict")
+	}
+
+	err = &APIError{Kind: ErrorValidation, StatusCode: http.StatusBadRequest}
+	if errors.Is(err, ErrRevisionConflict) {
+		t.Errorf("expected validation error not to match ErrRevisionConflict")
+	}
+}
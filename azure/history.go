@@ -0,0 +1,175 @@
+package azure
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// bookkeepingFields are changed by Azure DevOps on every update, so they are left
+// out of the changes of updates and of the differences between revisions
+var bookkeepingFields = []string{
+	FieldID,
+	FieldRev,
+	FieldChangedDate,
+	FieldChangedBy,
+	"System.RevisedDate",
+	"System.AuthorizedDate",
+	"System.AuthorizedAs",
+	"System.PersonId",
+	"System.Watermark",
+}
+
+// FieldChange is a field of a work item changed by an update, with the display
+// values before and after the update
+type FieldChange struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+// Update is a change to a work item, which made one of its revisions
+type Update struct {
+	Rev       int
+	RevisedBy string
+	Date      time.Time
+	// Fields are the changed fields ordered by reference name
+	Fields           []FieldChange
+	AddedRelations   []Relation
+	RemovedRelations []Relation
+}
+
+// maxUpdatesPage is the maximum number of updates the API returns per request
+const maxUpdatesPage = 200
+
+// updateResponse represents an update of a work item returned by the API
+type updateResponse struct {
+	Rev       int `json:"rev"`
+	RevisedBy struct {
+		DisplayName string `json:"displayName"`
+	} `json:"revisedBy"`
+	RevisedDate time.Time `json:"revisedDate"`
+	Fields      map[string]struct {
+		OldValue any `json:"oldValue"`
+		NewValue any `json:"newValue"`
+	} `json:"fields"`
+	Relations struct {
+		Added   []relationResponse `json:"added"`
+		Removed []relationResponse `json:"removed"`
+	} `json:"relations"`
+}
+
+// updatesResponse represents a page of the updates of a work item
+type updatesResponse struct {
+	Count int              `json:"count"`
+	Value []updateResponse `json:"value"`
+}
+
+// GetUpdates returns the updates of a work item, oldest first
+func (c *AzureClient) GetUpdates(id int) ([]Update, error) {
+	var updates []Update
+	for {
+		apiURL := fmt.Sprintf("%s/%s/%s/_apis/wit/workItems/%d/updates?$top=%d&$skip=%d&api-version=7.0",
+			c.BaseURL,
+			url.PathEscape(c.Organization),
+			url.PathEscape(c.Project),
+			id,
+			maxUpdatesPage,
+			len(updates))
+
+		req, err := http.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		c.setHeaders(req)
+
+		var page updatesResponse
+		if err := c.do(req, &page); err != nil {
+			return nil, err
+		}
+
+		for _, update := range page.Value {
+			updates = append(updates, convertToUpdate(update))
+		}
+		if len(page.Value) < maxUpdatesPage {
+			return updates, nil
+		}
+	}
+}
+
+// convertToUpdate converts an update returned by the API to an Update
+func convertToUpdate(u updateResponse) Update {
+	update := Update{
+		Rev:       u.Rev,
+		RevisedBy: u.RevisedBy.DisplayName,
+		Date:      u.RevisedDate,
+	}
+	// The revised date is when the revision was superseded, so the date of the
+	// change is taken from the changed date when it is part of the update
+	if changed, ok := u.Fields[FieldChangedDate].NewValue.(string); ok {
+		if t, err := time.Parse(time.RFC3339, changed); err == nil {
+			update.Date = t
+		}
+	}
+
+	for _, ref := range slices.Sorted(maps.Keys(u.Fields)) {
+		if slices.Contains(bookkeepingFields, ref) {
+			continue
+		}
+		update.Fields = append(update.Fields, FieldChange{
+			Field:    ref,
+			OldValue: formatFieldValue(u.Fields[ref].OldValue),
+			NewValue: formatFieldValue(u.Fields[ref].NewValue),
+		})
+	}
+	for _, rel := range u.Relations.Added {
+		update.AddedRelations = append(update.AddedRelations, convertToRelation(rel))
+	}
+	for _, rel := range u.Relations.Removed {
+		update.RemovedRelations = append(update.RemovedRelations, convertToRelation(rel))
+	}
+	return update
+}
+
+// DiffRevisions returns the fields whose values differ between two revisions of a
+// work item and the links added and removed in between, as an update from one
+// revision to the other
+func DiffRevisions(from, to WorkItem) Update {
+	update := Update{Rev: to.Rev, Date: to.Changed()}
+
+	refs := slices.Clone(detailFields)
+	for ref := range from.Fields {
+		refs = append(refs, ref)
+	}
+	for ref := range to.Fields {
+		refs = append(refs, ref)
+	}
+	slices.Sort(refs)
+	for _, ref := range slices.Compact(refs) {
+		if slices.Contains(bookkeepingFields, ref) {
+			continue
+		}
+		if before, after := from.Field(ref), to.Field(ref); before != after {
+			update.Fields = append(update.Fields, FieldChange{Field: ref, OldValue: before, NewValue: after})
+		}
+	}
+
+	sameLink := func(a Relation) func(Relation) bool {
+		return func(b Relation) bool { return a.Rel == b.Rel && a.URL == b.URL }
+	}
+	for _, rel := range to.Relations {
+		if !slices.ContainsFunc(from.Relations, sameLink(rel)) {
+			update.AddedRelations = append(update.AddedRelations, rel)
+		}
+	}
+	for _, rel := range from.Relations {
+		if !slices.ContainsFunc(to.Relations, sameLink(rel)) {
+			update.RemovedRelations = append(update.RemovedRelations, rel)
+		}
+	}
+	return update
+}
@@ -0,0 +1,102 @@
+package azure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestGetUpdates checks that field and link changes are read without the fields
+// Azure DevOps changes on every update
+func TestGetUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/project/_apis/wit/workItems/42/updates" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"count": 1, "value": [{
+			"id": 2, "workItemId": 42, "rev": 2,
+			"revisedBy": {"displayName": "John Doe"},
+			"revisedDate": "9999-01-01T00:00:00Z",
+			"fields": {
+				"System.Rev": {"oldValue": 1, "newValue": 2},
+				"System.ChangedDate": {"oldValue": "2024-03-04T09:00:00Z", "newValue": "2024-03-05T10:30:00Z"},
+				"System.State": {"oldValue": "New", "newValue": "Active"},
+				"System.AssignedTo": {"newValue": {"displayName": "John Doe", "uniqueName": "john@example.com"}}
+			},
+			"relations": {
+				"added": [{"rel": "System.LinkTypes.Hierarchy-Reverse", "url": "https://dev.azure.com/org/_apis/wit/workItems/7"}]
+			}
+		}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	updates, err := client.GetUpdates(42)
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("got %d updates, want 1", len(updates))
+	}
+	update := updates[0]
+	if update.Rev != 2 || update.RevisedBy != "John Doe" || !update.Date.Equal(time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("update = %+v", update)
+	}
+	want := []FieldChange{
+		{Field: FieldAssignedTo, OldValue: "", NewValue: "John Doe"},
+		{Field: FieldState, OldValue: "New", NewValue: "Active"},
+	}
+	if len(update.Fields) != len(want) || update.Fields[0] != want[0] || update.Fields[1] != want[1] {
+		t.Errorf("fields = %+v, want %+v", update.Fields, want)
+	}
+	if len(update.AddedRelations) != 1 || update.AddedRelations[0].TargetID != 7 {
+		t.Errorf("added relations = %+v", update.AddedRelations)
+	}
+}
+
+// TestDiffRevisions checks the changes between two revisions and that the mock
+// client reports its updates
+func TestDiffRevisions(t *testing.T) {
+	client := NewMockAzureClient()
+	item, err := client.GetWorkItem(1004)
+	if err != nil {
+		t.Fatalf("GetWorkItem failed: %v", err)
+	}
+	if _, err := client.UpdateWorkItem(item.ID, item.Rev, []PatchOperation{
+		SetField(FieldState, "Resolved"),
+		SetField(FieldRemainingWork, 0),
+		AddRelation(RelRelated, client.WorkItemURL(2002), ""),
+	}); err != nil {
+		t.Fatalf("UpdateWorkItem failed: %v", err)
+	}
+
+	revisions, err := client.GetRevisions([]int{item.ID})
+	if err != nil {
+		t.Fatalf("GetRevisions failed: %v", err)
+	}
+	revs := revisions[item.ID]
+	diff := DiffRevisions(revs[len(revs)-2], revs[len(revs)-1])
+	want := []FieldChange{
+		{Field: FieldRemainingWork, OldValue: "5", NewValue: "0"},
+		{Field: FieldState, OldValue: "Active", NewValue: "Resolved"},
+	}
+	if len(diff.Fields) != len(want) || diff.Fields[0] != want[0] || diff.Fields[1] != want[1] {
+		t.Errorf("fields = %+v, want %+v", diff.Fields, want)
+	}
+	if len(diff.AddedRelations) != 1 || diff.AddedRelations[0].TargetID != 2002 || len(diff.RemovedRelations) != 0 {
+		t.Errorf("relations added %+v, removed %+v", diff.AddedRelations, diff.RemovedRelations)
+	}
+
+	updates, err := client.GetUpdates(item.ID)
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	last := updates[len(updates)-1]
+	if last.Rev != revs[len(revs)-1].Rev || last.RevisedBy != mockAuthor || len(last.Fields) != 2 {
+		t.Errorf("last update = %+v", last)
+	}
+}
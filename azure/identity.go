@@ -0,0 +1,159 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Identity is a user work items can be assigned to
+type Identity struct {
+	ID          string
+	DisplayName string
+	// UniqueName is the sign-in address of the user
+	UniqueName string
+}
+
+// Ref returns the value identity fields are set to, which Azure DevOps resolves to the user
+func (i Identity) Ref() string {
+	switch {
+	case i.UniqueName == "":
+		return i.DisplayName
+	case i.DisplayName == "":
+		return i.UniqueName
+	default:
+		return fmt.Sprintf("%s <%s>", i.DisplayName, i.UniqueName)
+	}
+}
+
+// identityFromField reads an identity field value returned by the API
+func identityFromField(value any) (Identity, bool) {
+	v, ok := value.(map[string]any)
+	if !ok {
+		return Identity{}, false
+	}
+	var identity Identity
+	identity.ID, _ = v["id"].(string)
+	identity.DisplayName, _ = v["displayName"].(string)
+	identity.UniqueName, _ = v["uniqueName"].(string)
+	return identity, true
+}
+
+// teamMembersResponse represents the response when listing the members of a team
+type teamMembersResponse struct {
+	Count int `json:"count"`
+	Value []struct {
+		Identity struct {
+			ID          string `json:"id"`
+			DisplayName string `json:"displayName"`
+			UniqueName  string `json:"uniqueName"`
+		} `json:"identity"`
+	} `json:"value"`
+}
+
+// GetTeamMembers returns the members of the client's team
+func (c *AzureClient) GetTeamMembers() ([]Identity, error) {
+	team, err := c.team()
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/_apis/projects/%s/teams/%s/members?api-version=7.0",
+		c.BaseURL,
+		url.PathEscape(c.Organization),
+		url.PathEscape(c.Project),
+		url.PathEscape(team))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var membersResp teamMembersResponse
+	if err := c.do(req, &membersResp); err != nil {
+		return nil, err
+	}
+
+	members := make([]Identity, 0, len(membersResp.Value))
+	for _, m := range membersResp.Value {
+		members = append(members, Identity{
+			ID:          m.Identity.ID,
+			DisplayName: m.Identity.DisplayName,
+			UniqueName:  m.Identity.UniqueName,
+		})
+	}
+	return members, nil
+}
+
+// identitySearchRequest represents the request body of the identity picker
+type identitySearchRequest struct {
+	Query           string         `json:"query"`
+	IdentityTypes   []string       `json:"identityTypes"`
+	OperationScopes []string       `json:"operationScopes"`
+	Options         map[string]int `json:"options"`
+}
+
+// identitySearchResponse represents the identities found by the identity picker
+type identitySearchResponse struct {
+	Results []struct {
+		Identities []struct {
+			LocalID       string `json:"localId"`
+			DisplayName   string `json:"displayName"`
+			SignInAddress string `json:"signInAddress"`
+			Mail          string `json:"mail"`
+		} `json:"identities"`
+	} `json:"results"`
+}
+
+// maxIdentityResults limits how many identities a search returns
+const maxIdentityResults = 20
+
+// SearchIdentities returns the users of the organization whose name or
+// sign-in address starts with the query
+func (c *AzureClient) SearchIdentities(query string) ([]Identity, error) {
+	apiURL := fmt.Sprintf("%s/%s/_apis/IdentityPicker/Identities?api-version=7.1-preview.1",
+		c.BaseURL,
+		url.PathEscape(c.Organization))
+
+	body, err := json.Marshal(identitySearchRequest{
+		Query:           query,
+		IdentityTypes:   []string{"user"},
+		OperationScopes: []string{"ims", "source"},
+		Options:         map[string]int{"MinResults": 1, "MaxResults": maxIdentityResults},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal identity search: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var searchResp identitySearchResponse
+	if err := c.do(req, &searchResp); err != nil {
+		return nil, err
+	}
+
+	var identities []Identity
+	for _, result := range searchResp.Results {
+		for _, i := range result.Identities {
+			uniqueName := i.SignInAddress
+			if uniqueName == "" {
+				uniqueName = i.Mail
+			}
+			identities = append(identities, Identity{
+				ID:          i.LocalID,
+				DisplayName: i.DisplayName,
+				UniqueName:  uniqueName,
+			})
+		}
+	}
+	return identities, nil
+}
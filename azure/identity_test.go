@@ -0,0 +1,127 @@
+package azure
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// TestIdentityRef checks the values identity fields are set to
+func TestIdentityRef(t *testing.T) {
+	tests := []struct {
+		identity Identity
+		want     string
+	}{
+		{Identity{DisplayName: "Jane Smith", UniqueName: "jane@example.com"}, "Jane Smith <jane@example.com>"},
+		{Identity{UniqueName: "jane@example.com"}, "jane@example.com"},
+		{Identity{DisplayName: "Jane Smith"}, "Jane Smith"},
+		{Identity{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.identity.Ref(); got != tt.want {
+			t.Errorf("%+v.Ref() = %q, want %q", tt.identity, got, tt.want)
+		}
+	}
+}
+
+// TestGetTeamMembers checks that the default team of the project is looked up
+// and that its members are read
+func TestGetTeamMembers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/_apis/projects/project":
+			w.Write([]byte(`{"id": "1", "name": "project", "defaultTeam": {"id": "2", "name": "Web Team"}}`))
+		case "/org/_apis/projects/project/teams/Web Team/members":
+			json.NewEncoder(w).Encode(map[string]any{
+				"count": 1,
+				"value": []map[string]any{
+					{"identity": map[string]string{"id": "42", "displayName": "Jane Smith", "uniqueName": "jane@example.com"}},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	members, err := client.GetTeamMembers()
+	if err != nil {
+		t.Fatalf("GetTeamMembers failed: %v", err)
+	}
+	want := []Identity{{ID: "42", DisplayName: "Jane Smith", UniqueName: "jane@example.com"}}
+	if !slices.Equal(members, want) {
+		t.Errorf("members = %+v, want %+v", members, want)
+	}
+}
+
+// TestSearchIdentities checks that the query is sent to the identity picker
+// and that users without a sign-in address fall back to their mail
+func TestSearchIdentities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/org/_apis/IdentityPicker/Identities" {
+			http.NotFound(w, r)
+			return
+		}
+		var body identitySearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Query != "ja" {
+			t.Errorf("request query = %q (%v), want ja", body.Query, err)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"results": []map[string]any{{
+				"identities": []map[string]string{
+					{"localId": "42", "displayName": "Jane Smith", "signInAddress": "jane@example.com"},
+					{"localId": "43", "displayName": "Jack Brown", "mail": "jack@example.com"},
+				},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	identities, err := client.SearchIdentities("ja")
+	if err != nil {
+		t.Fatalf("SearchIdentities failed: %v", err)
+	}
+	want := []Identity{
+		{ID: "42", DisplayName: "Jane Smith", UniqueName: "jane@example.com"},
+		{ID: "43", DisplayName: "Jack Brown", UniqueName: "jack@example.com"},
+	}
+	if !slices.Equal(identities, want) {
+		t.Errorf("identities = %+v, want %+v", identities, want)
+	}
+}
+
+// TestMockAssignIdentity checks that the mock resolves identity references and rejects unknown users
+func TestMockAssignIdentity(t *testing.T) {
+	client := NewMockAzureClient()
+	item, err := client.GetWorkItem(1001)
+	if err != nil {
+		t.Fatalf("GetWorkItem failed: %v", err)
+	}
+
+	updated, err := client.UpdateWorkItem(item.ID, item.Rev, []PatchOperation{
+		SetField(FieldAssignedTo, mockIdentity("sarah").Ref()),
+	})
+	if err != nil {
+		t.Fatalf("UpdateWorkItem failed: %v", err)
+	}
+	if updated.AssignedTo != "sarah" || updated.Assignee != mockIdentity("sarah") {
+		t.Errorf("assigned to %q (%+v), want sarah", updated.AssignedTo, updated.Assignee)
+	}
+
+	_, err = client.UpdateWorkItem(updated.ID, updated.Rev, []PatchOperation{
+		SetField(FieldAssignedTo, "nobody@example.com"),
+	})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Kind != ErrorValidation {
+		t.Errorf("assigning an unknown user: err = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,45 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// workItemTypesResponse represents the response when listing work item types
+type workItemTypesResponse struct {
+	Count int `json:"count"`
+	Value []struct {
+		Name       string `json:"name"`
+		IsDisabled bool   `json:"isDisabled"`
+	} `json:"value"`
+}
+
+// GetWorkItemTypes returns the enabled work item types of the project
+func (c *AzureClient) GetWorkItemTypes() ([]WorkItemType, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s/_apis/wit/workitemtypes?api-version=7.0",
+		c.BaseURL,
+		url.PathEscape(c.Organization),
+		url.PathEscape(c.Project))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var typesResp workItemTypesResponse
+	if err := c.do(req, &typesResp); err != nil {
+		return nil, err
+	}
+
+	types := make([]WorkItemType, 0, len(typesResp.Value))
+	for _, t := range typesResp.Value {
+		if !t.IsDisabled {
+			types = append(types, WorkItemType(t.Name))
+		}
+	}
+
+	return types, nil
+}
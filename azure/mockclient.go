@@ -1,17 +1,902 @@
 // Package azure provides Azure DevOps client implementations.
 package azure
 
-// MockAzureClient provides mock data for testing
-type MockAzureClient struct{}
+import (
+	"cmp"
+	"fazure/markup"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mockAuthor is the author of comments posted through the mock client
+const mockAuthor = "you"
+
+// MockAzureClient provides mock data for testing and offline use.
+// Changes made through it are kept in memory for the lifetime of the client.
+type MockAzureClient struct {
+	mu            sync.Mutex
+	items         []*WorkItem
+	workflows     map[WorkItemType]*Workflow
+	areas         *ClassificationNode
+	iterations    *ClassificationNode
+	revisions     map[int][]WorkItem
+	nextCommentID int
+}
 
 // NewMockAzureClient creates a new mock Azure DevOps client
 func NewMockAzureClient() *MockAzureClient {
-	return &MockAzureClient{}
+	c := &MockAzureClient{
+		workflows:     mockWorkflows(),
+		areas:         mockAreas(),
+		iterations:    mockIterations(time.Now()),
+		revisions:     map[int][]WorkItem{},
+		nextCommentID: 1,
+	}
+	for _, items := range mockWorkItems() {
+		for _, item := range items {
+			item.Rev = 1
+			item.ChangedDate = item.CreatedDate
+			if item.AssignedTo != "" {
+				item.Assignee = mockIdentity(item.AssignedTo)
+			}
+			for ref, value := range item.Fields {
+				setMockField(&item, ref, value)
+			}
+			for i := range item.Comments {
+				item.Comments[i].ID = c.nextCommentID
+				c.nextCommentID++
+			}
+			c.items = append(c.items, &item)
+		}
+	}
+	slices.SortFunc(c.items, func(a, b *WorkItem) int {
+		return a.ID - b.ID
+	})
+	if item, err := c.find(1004); err == nil {
+		setMockField(item, mockLaneField, "Expedite")
+	}
+	for _, child := range slices.Sorted(maps.Keys(mockParents)) {
+		parent := mockParents[child]
+		c.link(child, RelParent, parent)
+		c.link(parent, RelChild, child)
+	}
+	c.seedRevisions(time.Now())
+	return c
+}
+
+// mockParents maps mock work items to their parents
+var mockParents = map[int]int{
+	1002: 1001,
+	1003: 1002,
+	1004: 1002,
+	1005: 1002,
+	1006: 1002,
+	2002: 2001,
+}
+
+// link adds a relation between two stored mock work items
+func (c *MockAzureClient) link(id int, rel string, target int) {
+	item, err := c.find(id)
+	if err != nil {
+		return
+	}
+	item.Relations = append(item.Relations, Relation{
+		Rel:      rel,
+		URL:      c.WorkItemURL(target),
+		TargetID: target,
+	})
+}
+
+// mirrorLinks updates the reverse links on the targets of links that were added
+// to or removed from a mock work item, like Azure DevOps does
+func (c *MockAzureClient) mirrorLinks(id int, before, after []Relation) {
+	sameLink := func(a Relation) func(Relation) bool {
+		return func(b Relation) bool { return a.Rel == b.Rel && a.TargetID == b.TargetID }
+	}
+	for _, rel := range before {
+		reverse := ReverseRel(rel.Rel)
+		if reverse == "" || rel.TargetID == 0 || slices.ContainsFunc(after, sameLink(rel)) {
+			continue
+		}
+		if target, err := c.find(rel.TargetID); err == nil {
+			target.Relations = slices.DeleteFunc(slices.Clone(target.Relations), sameLink(Relation{Rel: reverse, TargetID: id}))
+			c.touch(target)
+		}
+	}
+	for _, rel := range after {
+		reverse := ReverseRel(rel.Rel)
+		if reverse == "" || rel.TargetID == 0 || slices.ContainsFunc(before, sameLink(rel)) {
+			continue
+		}
+		if target, err := c.find(rel.TargetID); err == nil {
+			c.link(target.ID, reverse, id)
+			c.touch(target)
+		}
+	}
+}
+
+// QueryWorkItems returns the mock work items matching the given parameters
+func (c *MockAzureClient) QueryWorkItems(params QueryParams) ([]WorkItem, error) {
+	params, err := resolveIteration(params, c.GetCurrentIteration)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items := []WorkItem{}
+	for _, item := range c.items {
+		if matchesMockQuery(item, params) {
+			items = append(items, cloneWorkItem(item))
+		}
+	}
+
+	// The mock keeps items in backlog order, so sorting by rank keeps their position
+	slices.SortStableFunc(items, func(a, b WorkItem) int {
+		for _, key := range params.OrderBy {
+			var order int
+			switch key.Field {
+			case SortByStackRank, SortByBacklogPriority:
+			case SortByPriority:
+				order = cmp.Compare(a.Priority, b.Priority)
+			case SortByID:
+				order = cmp.Compare(a.ID, b.ID)
+			default:
+				order = strings.Compare(a.Field(string(key.Field)), b.Field(string(key.Field)))
+			}
+			if key.Descending {
+				order = -order
+			}
+			if order != 0 {
+				return order
+			}
+		}
+		return 0
+	})
+	return items, nil
+}
+
+// GetWorkItem returns a single mock work item by ID
+func (c *MockAzureClient) GetWorkItem(id int) (*WorkItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, err := c.find(id)
+	if err != nil {
+		return nil, err
+	}
+	wi := cloneWorkItem(item)
+	return &wi, nil
+}
+
+// CreateWorkItem adds a mock work item
+func (c *MockAzureClient) CreateWorkItem(itemType WorkItemType, ops []PatchOperation) (*WorkItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now().Format("2006-01-02 15:04")
+	item := WorkItem{
+		ID:          c.items[len(c.items)-1].ID + 1,
+		Rev:         1,
+		Type:        itemType,
+		State:       "New",
+		AreaPath:    mockProject,
+		Iteration:   mockProject,
+		CreatedBy:   mockAuthor,
+		CreatedDate: now,
+		ChangedDate: now,
+	}
+	for _, op := range ops {
+		if err := applyMockOp(&item, op); err != nil {
+			return nil, err
+		}
+	}
+	if item.Title == "" {
+		return nil, &APIError{Kind: ErrorValidation, Message: "the Title field cannot be empty"}
+	}
+	if err := c.checkPaths(&item); err != nil {
+		return nil, err
+	}
+
+	setMockField(&item, FieldChangedBy, mockAuthor)
+	c.items = append(c.items, &item)
+	c.record(&item)
+	c.mirrorLinks(item.ID, nil, item.Relations)
+
+	wi := cloneWorkItem(&item)
+	return &wi, nil
+}
+
+// UpdateWorkItem applies patch operations to a mock work item
+func (c *MockAzureClient) UpdateWorkItem(id, rev int, ops []PatchOperation) (*WorkItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, err := c.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Rev != rev {
+		return nil, fmt.Errorf("%w: work item %d is at revision %d", ErrRevisionConflict, id, item.Rev)
+	}
+
+	updated := cloneWorkItem(item)
+	for _, op := range ops {
+		if err := applyMockOp(&updated, op); err != nil {
+			return nil, err
+		}
+	}
+	if workflow, ok := c.workflows[item.Type]; ok && !workflow.CanMove(item.State, updated.State) {
+		return nil, &APIError{
+			Kind:    ErrorValidation,
+			Message: fmt.Sprintf("%s cannot move from %s to %s", item.Type, item.State, updated.State),
+		}
+	}
+	if err := c.checkPaths(&updated); err != nil {
+		return nil, err
+	}
+	if updated.State != item.State && !slices.ContainsFunc(ops, func(op PatchOperation) bool {
+		return op.Path == "/fields/"+mockColumnField
+	}) {
+		// Like Azure DevOps, move the card to the column of its new state
+		delete(updated.Fields, mockColumnField)
+		delete(updated.Fields, mockDoneField)
+	}
+	c.mirrorLinks(id, item.Relations, updated.Relations)
+	*item = updated
+	c.touch(item)
+
+	wi := cloneWorkItem(item)
+	return &wi, nil
+}
+
+// touch makes a new revision of a mock work item changed through the mock client
+func (c *MockAzureClient) touch(item *WorkItem) {
+	item.Rev++
+	item.ChangedDate = time.Now().Format("2006-01-02 15:04")
+	setMockField(item, FieldChangedBy, mockAuthor)
+	c.record(item)
+}
+
+// record stores the current state of a mock work item as its latest revision
+func (c *MockAzureClient) record(item *WorkItem) {
+	c.revisions[item.ID] = append(c.revisions[item.ID], cloneWorkItem(item))
+}
+
+// seedRevisions gives the mock work items a history. Estimated work items in the
+// current sprint are created on its first day and burned down over the days since,
+// so that the sprint has a burndown; other work items have a single revision.
+func (c *MockAzureClient) seedRevisions(now time.Time) {
+	sprint, err := c.GetCurrentIteration()
+	var days []time.Time
+	if err == nil {
+		capacity, _ := c.GetCapacity(sprint)
+		for _, day := range capacity.Days() {
+			if !day.After(dayOf(now)) {
+				days = append(days, day)
+			}
+		}
+	}
+
+	for _, item := range c.items {
+		if len(days) == 0 || item.OriginalEstimate == 0 || !UnderPath(item.Iteration, sprint.Path) {
+			c.record(item)
+			continue
+		}
+
+		for i, day := range days {
+			rev := cloneWorkItem(item)
+			rev.Rev = i + 1
+			rev.ChangedDate = time.Date(day.Year(), day.Month(), day.Day(), 17, 0, 0, 0, time.Local).Format("2006-01-02 15:04")
+			progress := 1.0
+			if i < len(days)-1 {
+				// Work is logged every few days
+				progress = float64(max(i-(i+item.ID)%3, 0)) / float64(len(days)-1)
+				rev.State = "Active"
+				if i == 0 {
+					rev.State = "New"
+				}
+			}
+			burned := math.Round((item.OriginalEstimate - item.RemainingWork) * progress)
+			setMockField(&rev, FieldChangedBy, item.AssignedTo)
+			setMockField(&rev, FieldRemainingWork, item.OriginalEstimate-burned)
+			setMockField(&rev, FieldCompletedWork, math.Round(item.CompletedWork*progress))
+			c.revisions[item.ID] = append(c.revisions[item.ID], rev)
+		}
+		item.Rev = len(days)
+		item.ChangedDate = c.revisions[item.ID][len(days)-1].ChangedDate
+	}
+}
+
+// GetRevisions returns every revision of the given mock work items, oldest first
+func (c *MockAzureClient) GetRevisions(ids []int) (map[int][]WorkItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	revisions := make(map[int][]WorkItem, len(ids))
+	for _, id := range ids {
+		revs, ok := c.revisions[id]
+		if !ok {
+			return nil, &APIError{Kind: ErrorNotFound, Message: fmt.Sprintf("work item %d not found", id)}
+		}
+		for _, rev := range revs {
+			revisions[id] = append(revisions[id], cloneWorkItem(&rev))
+		}
+	}
+	return revisions, nil
+}
+
+// GetUpdates returns the updates of a mock work item, from the differences between its revisions
+func (c *MockAzureClient) GetUpdates(id int) ([]Update, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	revs, ok := c.revisions[id]
+	if !ok {
+		return nil, &APIError{Kind: ErrorNotFound, Message: fmt.Sprintf("work item %d not found", id)}
+	}
+	var updates []Update
+	var prev WorkItem
+	for _, rev := range revs {
+		update := DiffRevisions(prev, rev)
+		update.RevisedBy, _ = rev.Fields[FieldChangedBy].(string)
+		if update.RevisedBy == "" {
+			update.RevisedBy = rev.CreatedBy
+		}
+		updates = append(updates, update)
+		prev = rev
+	}
+	return updates, nil
+}
+
+// GetWorkItems returns several mock work items by ID, leaving out missing ones
+func (c *MockAzureClient) GetWorkItems(ids []int) ([]WorkItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items := []WorkItem{}
+	for _, id := range ids {
+		if item, err := c.find(id); err == nil {
+			items = append(items, cloneWorkItem(item))
+		}
+	}
+	return items, nil
+}
+
+// WorkItemURL returns the URL that identifies a mock work item as the target of links
+func (c *MockAzureClient) WorkItemURL(id int) string {
+	return fmt.Sprintf("https://dev.azure.com/mock/_apis/wit/workItems/%d", id)
+}
+
+// GetComments returns the discussion of a mock work item
+func (c *MockAzureClient) GetComments(id int) ([]Comment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, err := c.find(id)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(item.Comments), nil
+}
+
+// AddComment adds a comment to the discussion of a mock work item
+func (c *MockAzureClient) AddComment(id int, text string) (*Comment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, err := c.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := Comment{
+		ID:      c.nextCommentID,
+		Author:  mockAuthor,
+		Date:    time.Now().Format("2006-01-02 15:04"),
+		Content: markup.FromPlain(text),
+	}
+	c.nextCommentID++
+	item.Comments = append(slices.Clone(item.Comments), comment)
+	return &comment, nil
+}
+
+// GetWorkItemTypes returns the work item types used by the mock data
+func (c *MockAzureClient) GetWorkItemTypes() ([]WorkItemType, error) {
+	return []WorkItemType{Initiative, Requirement, UserStory, Task, Bug}, nil
+}
+
+// GetWorkflow returns the workflow of a mock work item type
+func (c *MockAzureClient) GetWorkflow(itemType WorkItemType) (*Workflow, error) {
+	workflow, ok := c.workflows[itemType]
+	if !ok {
+		return nil, &APIError{Kind: ErrorNotFound, Message: fmt.Sprintf("work item type %s not found", itemType)}
+	}
+	return workflow, nil
+}
+
+// Fields holding the position of mock work items on the boards of the mock team
+const (
+	mockColumnField = "WEF_FAZURE_Kanban.Column"
+	mockDoneField   = "WEF_FAZURE_Kanban.Column.Done"
+	mockLaneField   = "WEF_FAZURE_Kanban.Lane"
+)
+
+// mockBoards returns the boards of the mock team
+func mockBoards() []*Board {
+	column := func(name, columnType string, limit int, split bool, mappings map[WorkItemType]string) BoardColumn {
+		return BoardColumn{Name: name, Type: columnType, ItemLimit: limit, IsSplit: split, StateMappings: mappings}
+	}
+	both := func(story, bug string) map[WorkItemType]string {
+		return map[WorkItemType]string{UserStory: story, Bug: bug}
+	}
+	fields := func(b *Board) *Board {
+		b.ColumnField, b.DoneField, b.LaneField = mockColumnField, mockDoneField, mockLaneField
+		return b
+	}
+	return []*Board{
+		fields(&Board{
+			ID:   "stories",
+			Name: "Stories",
+			Columns: []BoardColumn{
+				column("New", ColumnIncoming, 0, false, both("New", "New")),
+				column("Active", ColumnInProgress, 2, true, both("Active", "Active")),
+				column("Testing", ColumnInProgress, 3, false, both("In Progress", "Resolved")),
+				column("Resolved", ColumnInProgress, 0, false, map[WorkItemType]string{UserStory: "Resolved"}),
+				column("Closed", ColumnOutgoing, 0, false, both("Closed", "Closed")),
+			},
+			Lanes: []string{"", "Expedite"},
+		}),
+		fields(&Board{
+			ID:   "features",
+			Name: "Features",
+			Columns: []BoardColumn{
+				column("New", ColumnIncoming, 0, false, map[WorkItemType]string{Initiative: "New", Requirement: "New"}),
+				column("Planning", ColumnInProgress, 2, false, map[WorkItemType]string{Initiative: "Planning", Requirement: "Active"}),
+				column("In Progress", ColumnInProgress, 4, false, map[WorkItemType]string{Initiative: "In Progress", Requirement: "Resolved"}),
+				column("Done", ColumnOutgoing, 0, false, map[WorkItemType]string{Initiative: "Done", Requirement: "Closed"}),
+			},
+			Lanes: []string{""},
+		}),
+	}
 }
 
-// SearchWorkItems returns mock backlog items for a given user
-func (c *MockAzureClient) SearchWorkItems(assignedTo string) []WorkItem {
-	mockData := map[string][]WorkItem{
+// GetBoards returns the names of the mock team's boards
+func (c *MockAzureClient) GetBoards() ([]string, error) {
+	var names []string
+	for _, board := range mockBoards() {
+		names = append(names, board.Name)
+	}
+	return names, nil
+}
+
+// GetBoard returns one of the mock team's boards
+func (c *MockAzureClient) GetBoard(name string) (*Board, error) {
+	for _, board := range mockBoards() {
+		if board.Name == name || board.ID == name {
+			return board, nil
+		}
+	}
+	return nil, &APIError{Kind: ErrorNotFound, Message: fmt.Sprintf("board %s not found", name)}
+}
+
+// mockUsers are the users of the mock organization, the first mockTeamSize of which form the team
+var mockUsers = []string{"john", "sarah", "mike", "emma", "alice", "dave", "priya"}
+
+const mockTeamSize = 4
+
+// mockIdentity returns the identity of a mock user
+func mockIdentity(name string) Identity {
+	return Identity{ID: "mock-" + name, DisplayName: name, UniqueName: name + "@fazure.dev"}
+}
+
+// GetTeamMembers returns the members of the mock team
+func (c *MockAzureClient) GetTeamMembers() ([]Identity, error) {
+	members := make([]Identity, 0, mockTeamSize)
+	for _, name := range mockUsers[:mockTeamSize] {
+		members = append(members, mockIdentity(name))
+	}
+	return members, nil
+}
+
+// SearchIdentities returns the mock users whose name or sign-in address starts with the query
+func (c *MockAzureClient) SearchIdentities(query string) ([]Identity, error) {
+	query = strings.ToLower(query)
+	var identities []Identity
+	for _, name := range mockUsers {
+		identity := mockIdentity(name)
+		if strings.HasPrefix(identity.DisplayName, query) || strings.HasPrefix(identity.UniqueName, query) {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+// resolveMockIdentity finds the mock user an identity field value refers to, which
+// may be a display name, a sign-in address or both as "Name <address>"
+func resolveMockIdentity(value string) (Identity, error) {
+	if name, address, ok := strings.Cut(value, " <"); ok {
+		value = strings.TrimSuffix(address, ">")
+		if value == "" {
+			value = name
+		}
+	}
+	for _, name := range mockUsers {
+		identity := mockIdentity(name)
+		if strings.EqualFold(value, identity.DisplayName) || strings.EqualFold(value, identity.UniqueName) {
+			return identity, nil
+		}
+	}
+	return Identity{}, &APIError{Kind: ErrorValidation, Message: fmt.Sprintf("the identity %q is unknown", value)}
+}
+
+// mockProject is the name of the mock project and the root of its areas and iterations
+const mockProject = "FazureApp"
+
+// mockAreas returns the area tree of the mock project
+func mockAreas() *ClassificationNode {
+	node := func(id int, path string, children ...*ClassificationNode) *ClassificationNode {
+		name := path[strings.LastIndex(path, `\`)+1:]
+		return &ClassificationNode{ID: id, Name: name, Path: path, Children: children}
+	}
+	return node(1, mockProject,
+		node(2, mockProject+`\Backend`,
+			node(3, mockProject+`\Backend\Security`),
+			node(4, mockProject+`\Backend\Auth`),
+			node(5, mockProject+`\Backend\API`),
+		),
+		node(6, mockProject+`\Frontend`),
+		node(7, mockProject+`\Mobile`),
+		node(8, mockProject+`\Features`),
+	)
+}
+
+// mockIterations returns the two-week sprints of the mock project, scheduled
+// around now so that Sprint 23 is always the current one
+func mockIterations(now time.Time) *ClassificationNode {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := today.AddDate(0, 0, -4-14)
+
+	root := &ClassificationNode{ID: 100, Name: mockProject, Path: mockProject}
+	for sprint := 22; sprint <= 25; sprint++ {
+		name := fmt.Sprintf("Sprint %d", sprint)
+		root.Children = append(root.Children, &ClassificationNode{
+			ID:         100 + sprint,
+			Name:       name,
+			Path:       mockProject + `\` + name,
+			StartDate:  start,
+			FinishDate: start.AddDate(0, 0, 13),
+		})
+		start = start.AddDate(0, 0, 14)
+	}
+	return root
+}
+
+// GetClassificationNodes returns the area or iteration tree of the mock project
+func (c *MockAzureClient) GetClassificationNodes(group ClassificationGroup) (*ClassificationNode, error) {
+	switch group {
+	case Areas:
+		return c.areas, nil
+	case Iterations:
+		return c.iterations, nil
+	default:
+		return nil, &APIError{Kind: ErrorNotFound, Message: fmt.Sprintf("classification group %s not found", group)}
+	}
+}
+
+// GetCurrentIteration returns the mock sprint that contains today
+func (c *MockAzureClient) GetCurrentIteration() (*ClassificationNode, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for _, sprint := range c.iterations.Children {
+		if sprint.Contains(today) {
+			return sprint, nil
+		}
+	}
+	return nil, &APIError{Kind: ErrorNotFound, Message: "the team has no current iteration"}
+}
+
+// GetTeamAreas returns the areas of the mock team, which owns the whole project
+func (c *MockAzureClient) GetTeamAreas() ([]TeamArea, error) {
+	return []TeamArea{{Path: mockProject, IncludeChildren: true}}, nil
+}
+
+// mockActivities are the daily capacity of the mock team members who have capacity planned
+var mockActivities = map[string][]Activity{
+	"john":  {{Name: "Development", CapacityPerDay: 6}},
+	"sarah": {{Name: "Development", CapacityPerDay: 4}, {Name: "Testing", CapacityPerDay: 2}},
+	"mike":  {{Name: "Design", CapacityPerDay: 5}},
+	"emma":  {{Name: "Testing", CapacityPerDay: 3}},
+}
+
+// GetCapacity returns the capacity of the mock team in an iteration. Sarah takes the
+// third and fourth day of every sprint off and the team takes the tenth day off.
+func (c *MockAzureClient) GetCapacity(iteration *ClassificationNode) (*Capacity, error) {
+	capacity := &Capacity{
+		Iteration:   iteration,
+		WorkingDays: defaultWorkingDays,
+	}
+	start := iteration.StartDate
+	if !start.IsZero() {
+		capacity.TeamDaysOff = []DateRange{{Start: start.AddDate(0, 0, 9), End: start.AddDate(0, 0, 9)}}
+	}
+	for _, name := range mockUsers[:mockTeamSize] {
+		member := MemberCapacity{Member: mockIdentity(name), Activities: mockActivities[name]}
+		if name == "sarah" && !start.IsZero() {
+			member.DaysOff = []DateRange{{Start: start.AddDate(0, 0, 2), End: start.AddDate(0, 0, 3)}}
+		}
+		capacity.Members = append(capacity.Members, member)
+	}
+	return capacity, nil
+}
+
+// checkPaths rejects mock work items whose area or iteration does not exist, like Azure DevOps does
+func (c *MockAzureClient) checkPaths(wi *WorkItem) error {
+	if c.areas.Find(wi.AreaPath) == nil {
+		return &APIError{Kind: ErrorValidation, Message: fmt.Sprintf("the area path %q does not exist", wi.AreaPath)}
+	}
+	if c.iterations.Find(wi.Iteration) == nil {
+		return &APIError{Kind: ErrorValidation, Message: fmt.Sprintf("the iteration path %q does not exist", wi.Iteration)}
+	}
+	return nil
+}
+
+// matchesMockQuery reports whether a mock work item satisfies the query parameters
+func matchesMockQuery(item *WorkItem, params QueryParams) bool {
+	if params.AssignedTo != "" && item.AssignedTo != params.AssignedTo {
+		return false
+	}
+	if len(params.States) > 0 && !slices.Contains(params.States, item.State) {
+		return false
+	}
+	if slices.Contains(params.ExcludeStates, item.State) {
+		return false
+	}
+	if len(params.Types) > 0 && !slices.Contains(params.Types, item.Type) {
+		return false
+	}
+	if slices.Contains(params.ExcludeTypes, item.Type) {
+		return false
+	}
+	hasTag := func(tag string) bool {
+		return slices.ContainsFunc(item.Tags, func(t string) bool {
+			return strings.EqualFold(t, tag)
+		})
+	}
+	for _, tag := range params.Tags {
+		if !hasTag(tag) {
+			return false
+		}
+	}
+	if slices.ContainsFunc(params.ExcludeTags, hasTag) {
+		return false
+	}
+	if params.IterationPath != "" && !UnderPath(item.Iteration, params.IterationPath) {
+		return false
+	}
+	if params.AreaPath != "" && !UnderPath(item.AreaPath, params.AreaPath) {
+		return false
+	}
+	if len(params.Areas) > 0 && !slices.ContainsFunc(params.Areas, func(area TeamArea) bool {
+		if area.IncludeChildren {
+			return UnderPath(item.AreaPath, area.Path)
+		}
+		return strings.EqualFold(item.AreaPath, area.Path)
+	}) {
+		return false
+	}
+	if since := params.FinishedSince; !since.IsZero() && slices.Contains(TerminalStates, item.State) {
+		// WIQL compares dates by day
+		if item.Changed().Before(time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.Local)) {
+			return false
+		}
+	}
+	return true
+}
+
+// find returns the stored mock work item with the given ID
+func (c *MockAzureClient) find(id int) (*WorkItem, error) {
+	for _, item := range c.items {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+	return nil, &APIError{Kind: ErrorNotFound, Message: fmt.Sprintf("work item %d not found", id)}
+}
+
+// cloneWorkItem copies a work item so callers cannot modify the stored mock data
+func cloneWorkItem(item *WorkItem) WorkItem {
+	wi := *item
+	wi.Tags = slices.Clone(item.Tags)
+	wi.Comments = slices.Clone(item.Comments)
+	wi.Fields = maps.Clone(item.Fields)
+	wi.Relations = slices.Clone(item.Relations)
+	return wi
+}
+
+// applyMockOp applies a single patch operation to a mock work item
+func applyMockOp(wi *WorkItem, op PatchOperation) error {
+	if op.Path == "/relations/-" && op.Op == "add" {
+		value, ok := op.Value.(RelationValue)
+		if !ok {
+			return fmt.Errorf("unsupported relation value %v", op.Value)
+		}
+		rel := Relation{Rel: value.Rel, URL: value.URL, TargetID: LinkTargetID(value.URL)}
+		if comment, ok := value.Attributes["comment"].(string); ok {
+			rel.Comment = comment
+		}
+		if rel.Rel == RelParent && wi.ParentID() != 0 {
+			return &APIError{Kind: ErrorValidation, Message: fmt.Sprintf("work item %d already has a parent", wi.ID)}
+		}
+		wi.Relations = append(wi.Relations, rel)
+		return nil
+	}
+
+	if index, ok := strings.CutPrefix(op.Path, "/relations/"); ok && op.Op == "remove" {
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= len(wi.Relations) {
+			return &APIError{Kind: ErrorValidation, Message: fmt.Sprintf("work item %d has no relation %s", wi.ID, index)}
+		}
+		wi.Relations = slices.Delete(wi.Relations, i, i+1)
+		return nil
+	}
+
+	if op.Op != "add" && op.Op != "replace" {
+		return fmt.Errorf("unsupported patch operation %q on %s", op.Op, op.Path)
+	}
+	ref, ok := strings.CutPrefix(op.Path, "/fields/")
+	if !ok {
+		return fmt.Errorf("unsupported patch path %s", op.Path)
+	}
+	return setMockField(wi, ref, op.Value)
+}
+
+// setMockField sets a field of a mock work item from a patch value
+func setMockField(wi *WorkItem, ref string, value any) error {
+	text := fmt.Sprint(value)
+	if value == nil {
+		text = ""
+	}
+
+	switch ref {
+	case FieldTitle:
+		wi.Title = text
+	case FieldAssignedTo:
+		if text == "" {
+			wi.AssignedTo = ""
+			wi.Assignee = Identity{}
+			break
+		}
+		identity, err := resolveMockIdentity(text)
+		if err != nil {
+			return err
+		}
+		wi.AssignedTo = identity.DisplayName
+		wi.Assignee = identity
+	case FieldState:
+		wi.State = text
+	case FieldPriority:
+		priority, err := strconv.Atoi(text)
+		if err != nil {
+			return fmt.Errorf("invalid priority %q", text)
+		}
+		wi.Priority = priority
+	case FieldDescription:
+		wi.Description = text
+	case FieldAcceptanceCriteria:
+		wi.AcceptanceCriteria = text
+	case FieldTags:
+		wi.Tags = nil
+		if text != "" {
+			wi.Tags = strings.Split(text, "; ")
+		}
+	case FieldAreaPath:
+		wi.AreaPath = text
+	case FieldIterationPath:
+		wi.Iteration = text
+	case FieldStoryPoints, FieldOriginalEstimate, FieldRemainingWork, FieldCompletedWork:
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil && text != "" {
+			return &APIError{Kind: ErrorValidation, Message: fmt.Sprintf("%q is not a valid number for %s", text, ref)}
+		}
+		switch ref {
+		case FieldStoryPoints:
+			wi.StoryPoints = number
+		case FieldOriginalEstimate:
+			wi.OriginalEstimate = number
+		case FieldRemainingWork:
+			wi.RemainingWork = number
+		case FieldCompletedWork:
+			wi.CompletedWork = number
+		}
+		if wi.Fields == nil {
+			wi.Fields = map[string]any{}
+		}
+		wi.Fields[ref] = number
+		if text == "" {
+			delete(wi.Fields, ref)
+		}
+	case FieldID, FieldRev, FieldWorkItemType, FieldCreatedBy, FieldCreatedDate, FieldChangedDate:
+		return fmt.Errorf("field %s cannot be updated", ref)
+	default:
+		if wi.Fields == nil {
+			wi.Fields = map[string]any{}
+		}
+		wi.Fields[ref] = value
+	}
+	return nil
+}
+
+// mockWorkflows returns the workflows of the mock work item types
+func mockWorkflows() map[WorkItemType]*Workflow {
+	var (
+		proposed   = State{Name: "New", Category: CategoryProposed, Color: "b2b2b2"}
+		active     = State{Name: "Active", Category: CategoryInProgress, Color: "007acc"}
+		planning   = State{Name: "Planning", Category: CategoryInProgress, Color: "5688e0"}
+		inProgress = State{Name: "In Progress", Category: CategoryInProgress, Color: "007acc"}
+		resolved   = State{Name: "Resolved", Category: CategoryResolved, Color: "ff9d00"}
+		closed     = State{Name: "Closed", Category: CategoryCompleted, Color: "339933"}
+		done       = State{Name: "Done", Category: CategoryCompleted, Color: "339933"}
+		removed    = State{Name: "Removed", Category: CategoryRemoved, Color: "ffffff"}
+	)
+
+	return map[WorkItemType]*Workflow{
+		Initiative:  linearWorkflow(Initiative, proposed, planning, inProgress, done, removed),
+		Requirement: linearWorkflow(Requirement, proposed, active, resolved, closed, removed),
+		UserStory:   linearWorkflow(UserStory, proposed, active, inProgress, resolved, closed, removed),
+		Task:        linearWorkflow(Task, proposed, active, done, removed),
+		Bug:         linearWorkflow(Bug, proposed, active, resolved, closed, removed),
+	}
+}
+
+// linearWorkflow returns a workflow in which work items move forward or back one
+// state at a time, can be removed from any state and restored from removal
+func linearWorkflow(itemType WorkItemType, states ...State) *Workflow {
+	w := &Workflow{
+		Type:        itemType,
+		States:      states,
+		Transitions: map[string][]string{"": {states[0].Name}},
+	}
+
+	var flow []string
+	var removed string
+	for _, s := range states {
+		if s.Category == CategoryRemoved {
+			removed = s.Name
+		} else {
+			flow = append(flow, s.Name)
+		}
+	}
+
+	for i, name := range flow {
+		var to []string
+		if i < len(flow)-1 {
+			to = append(to, flow[i+1])
+		}
+		if i > 0 {
+			to = append(to, flow[i-1])
+		}
+		if removed != "" {
+			to = append(to, removed)
+		}
+		w.Transitions[name] = to
+	}
+	if removed != "" {
+		w.Transitions[removed] = []string{flow[0]}
+	}
+	return w
+}
+
+// mockWorkItems returns the mock backlog items grouped by assignee
+func mockWorkItems() map[string][]WorkItem {
+	return map[string][]WorkItem{
 		"john": {
 			{
 				ID: 1001, Type: Initiative, Title: "Implement new authentication system",
@@ -19,7 +904,7 @@ func (c *MockAzureClient) SearchWorkItems(assignedTo string) []WorkItem {
 				Description: "Modernize our authentication system to support OAuth2, SAML, and social logins. This initiative will improve security and user experience.",
 				CreatedBy:   "alice", CreatedDate: "2024-01-15",
 				Tags:     []string{"security", "authentication", "phase-1"},
-				AreaPath: "FazureApp\\Backend\\Security", Iteration: "Sprint 23",
+				AreaPath: "FazureApp\\Backend\\Security", Iteration: "FazureApp\\Sprint 23",
 				Comments: []Comment{
 					{Author: "alice", Date: "2024-01-15 09:30", Content: "Created this initiative to track our auth modernization efforts. Let's aim to complete this by end of Q1."},
 					{Author: "john", Date: "2024-01-16 14:20", Content: "Started working on the OAuth2 implementation. Will need help with the UI components."},
@@ -29,10 +914,12 @@ func (c *MockAzureClient) SearchWorkItems(assignedTo string) []WorkItem {
 			{
 				ID: 1002, Type: UserStory, Title: "Add OAuth2 login support",
 				AssignedTo: "john", State: "Active", Priority: 1,
-				Description: "As a user, I want to log in using my Google or GitHub account so that I don't have to remember another password.",
-				CreatedBy:   "alice", CreatedDate: "2024-01-16",
+				Description:        "<div>As a user, I want to log in using my <b>Google</b> or <b>GitHub</b> account so that I don&#39;t have to remember another password.</div>",
+				AcceptanceCriteria: "<ul><li>Both providers are offered on the <a href=\"https://fazure.dev/login\">login page</a></li><li>First-time users get an account created<ul><li>with their name and email from the provider</li></ul></li></ul>",
+				CreatedBy:          "alice", CreatedDate: "2024-01-16",
 				Tags:     []string{"oauth2", "user-story"},
-				AreaPath: "FazureApp\\Backend\\Auth", Iteration: "Sprint 23",
+				AreaPath: "FazureApp\\Backend\\Auth", Iteration: "FazureApp\\Sprint 23",
+				Fields: map[string]any{FieldStoryPoints: 5.0},
 				Comments: []Comment{
 					{Author: "alice", Date: "2024-01-16 11:00", Content: "This should integrate with Google and GitHub OAuth providers initially."},
 					{Author: "john", Date: "2024-01-18 15:45", Content: "Google OAuth is working! GitHub integration next."},
@@ -44,7 +931,8 @@ func (c *MockAzureClient) SearchWorkItems(assignedTo string) []WorkItem {
 				Description: "Design and implement the new login page with OAuth buttons and traditional login form.",
 				CreatedBy:   "john", CreatedDate: "2024-01-17",
 				Tags:     []string{"ui", "frontend"},
-				AreaPath: "FazureApp\\Frontend", Iteration: "Sprint 23",
+				AreaPath: "FazureApp\\Frontend", Iteration: "FazureApp\\Sprint 23",
+				Fields: map[string]any{FieldOriginalEstimate: 10.0, FieldRemainingWork: 0.0, FieldCompletedWork: 12.0},
 				Comments: []Comment{
 					{Author: "john", Date: "2024-01-17 09:00", Content: "Starting the UI work today. Using our design system components."},
 					{Author: "sarah", Date: "2024-01-17 16:30", Content: "Looks great! Make sure it's mobile responsive."},
@@ -54,24 +942,45 @@ func (c *MockAzureClient) SearchWorkItems(assignedTo string) []WorkItem {
 			{
 				ID: 1004, Type: Bug, Title: "Fix login redirect issue",
 				AssignedTo: "john", State: "Active", Priority: 1,
-				Description: "After successful OAuth login, users are redirected to /home instead of their originally requested page. Need to preserve the redirect URL through the OAuth flow.",
-				CreatedBy:   "emma", CreatedDate: "2024-01-20",
+				Description: "<div>After successful OAuth login, users are redirected to <code>/home</code> instead of their originally requested page. Need to preserve the redirect URL through the OAuth flow.</div><div><br></div>" +
+					"<div><b>Steps to reproduce</b></div><ol><li>Navigate to <code>/dashboard</code></li><li>Click <i>Log in</i></li><li>Complete OAuth</li></ol>" +
+					"<table><tr><th>Browser</th><th>Lands on</th></tr><tr><td>Chrome</td><td>/home</td></tr><tr><td>Safari</td><td>/home</td></tr></table>",
+				CreatedBy: "emma", CreatedDate: "2024-01-20",
 				Tags:     []string{"bug", "oauth", "critical"},
-				AreaPath: "FazureApp\\Backend\\Auth", Iteration: "Sprint 23",
+				AreaPath: "FazureApp\\Backend\\Auth", Iteration: "FazureApp\\Sprint 23",
+				Fields: map[string]any{FieldStoryPoints: 2.0, FieldOriginalEstimate: 8.0, FieldRemainingWork: 5.0, FieldCompletedWork: 3.0},
 				Comments: []Comment{
 					{Author: "emma", Date: "2024-01-20 10:15", Content: "Found this while testing. Steps to reproduce: 1) Navigate to /dashboard, 2) Click login, 3) Complete OAuth, 4) You end up at /home instead of /dashboard"},
 					{Author: "john", Date: "2024-01-20 11:30", Content: "Good catch! I'll fix this by storing the original URL in the session state."},
 				},
 			},
+			{
+				ID: 1005, Type: Task, Title: "Add GitHub OAuth provider",
+				AssignedTo: "john", State: "Active", Priority: 2,
+				Description: "Register the OAuth app with GitHub and add it as a login provider next to Google.",
+				CreatedBy:   "john", CreatedDate: "2024-01-18",
+				Tags:     []string{"oauth2"},
+				AreaPath: "FazureApp\\Backend\\Auth", Iteration: "FazureApp\\Sprint 23",
+				Fields: map[string]any{FieldOriginalEstimate: 16.0, FieldRemainingWork: 9.0, FieldCompletedWork: 7.0},
+			},
 		},
 		"sarah": {
+			{
+				ID: 1006, Type: Task, Title: "Write OAuth integration tests",
+				AssignedTo: "sarah", State: "Active", Priority: 2,
+				Description: "Cover the Google and GitHub login flows, including the redirect back to the requested page.",
+				CreatedBy:   "john", CreatedDate: "2024-01-18",
+				Tags:     []string{"oauth2", "testing"},
+				AreaPath: "FazureApp\\Backend\\Auth", Iteration: "FazureApp\\Sprint 23",
+				Fields: map[string]any{FieldOriginalEstimate: 12.0, FieldRemainingWork: 8.0, FieldCompletedWork: 4.0},
+			},
 			{
 				ID: 2001, Type: Requirement, Title: "API rate limiting requirements",
 				AssignedTo: "sarah", State: "New", Priority: 1,
 				Description: "Define requirements for API rate limiting to protect our services from abuse and ensure fair usage.",
 				CreatedBy:   "alice", CreatedDate: "2024-01-10",
 				Tags:     []string{"api", "requirements"},
-				AreaPath: "FazureApp\\Backend\\API", Iteration: "Sprint 24",
+				AreaPath: "FazureApp\\Backend\\API", Iteration: "FazureApp\\Sprint 24",
 				Comments: []Comment{
 					{Author: "alice", Date: "2024-01-10 14:00", Content: "We need to define rate limits per user tier and implement proper throttling."},
 				},
@@ -82,7 +991,8 @@ func (c *MockAzureClient) SearchWorkItems(assignedTo string) []WorkItem {
 				Description: "As an API owner, I want to rate limit requests per user to prevent abuse and ensure system stability.",
 				CreatedBy:   "sarah", CreatedDate: "2024-01-12",
 				Tags:     []string{"api", "middleware"},
-				AreaPath: "FazureApp\\Backend\\API", Iteration: "Sprint 24",
+				AreaPath: "FazureApp\\Backend\\API", Iteration: "FazureApp\\Sprint 24",
+				Fields: map[string]any{FieldStoryPoints: 8.0},
 				Comments: []Comment{
 					{Author: "sarah", Date: "2024-01-12 09:00", Content: "Starting implementation using Redis for distributed rate limiting."},
 					{Author: "john", Date: "2024-01-13 10:30", Content: "Make sure to add proper headers for rate limit status!"},
@@ -96,7 +1006,7 @@ func (c *MockAzureClient) SearchWorkItems(assignedTo string) []WorkItem {
 				Description: "Complete redesign of our mobile application with modern UI/UX patterns and improved performance.",
 				CreatedBy:   "alice", CreatedDate: "2024-01-05",
 				Tags:     []string{"mobile", "redesign", "ux"},
-				AreaPath: "FazureApp\\Mobile", Iteration: "Sprint 25",
+				AreaPath: "FazureApp\\Mobile", Iteration: "FazureApp\\Sprint 25",
 				Comments: []Comment{
 					{Author: "alice", Date: "2024-01-05 08:00", Content: "Let's make our mobile app shine! Focus on user experience and performance."},
 					{Author: "mike", Date: "2024-01-06 11:00", Content: "Working on the design mockups. Will share soon!"},
@@ -110,17 +1020,12 @@ func (c *MockAzureClient) SearchWorkItems(assignedTo string) []WorkItem {
 				Description: "As a user, I want to export my data in multiple formats (CSV, JSON, Excel) so I can analyze it in external tools.",
 				CreatedBy:   "alice", CreatedDate: "2024-01-08",
 				Tags:     []string{"export", "data"},
-				AreaPath: "FazureApp\\Features", Iteration: "Sprint 24",
+				AreaPath: "FazureApp\\Features", Iteration: "FazureApp\\Sprint 24",
+				Fields: map[string]any{FieldStoryPoints: 5.0},
 				Comments: []Comment{
 					{Author: "alice", Date: "2024-01-08 13:00", Content: "Users have been requesting this feature. Let's start with CSV."},
 				},
 			},
 		},
 	}
-
-	// Return items for the specified user, or empty slice if not found
-	if items, exists := mockData[assignedTo]; exists {
-		return items
-	}
-	return []WorkItem{}
 }
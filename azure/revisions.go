@@ -0,0 +1,78 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// maxRevisionsPage is the maximum number of revisions the API returns per request
+const maxRevisionsPage = 200
+
+// revisionsResponse represents a page of the revisions of a work item
+type revisionsResponse struct {
+	Count int                `json:"count"`
+	Value []workItemResponse `json:"value"`
+}
+
+// GetRevisions returns every revision of the work items with the given IDs, oldest
+// first, with the fields and links of the work item as they were after each change.
+// The work items are fetched with bounded concurrency.
+func (c *AzureClient) GetRevisions(ids []int) (map[int][]WorkItem, error) {
+	results := make([][]WorkItem, len(ids))
+	errs := make([]error, len(ids))
+
+	sem := make(chan struct{}, maxConcurrentBatches)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = c.getRevisions(id)
+		})
+	}
+	wg.Wait()
+
+	revisions := make(map[int][]WorkItem, len(ids))
+	for i, id := range ids {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		revisions[id] = results[i]
+	}
+	return revisions, nil
+}
+
+// getRevisions fetches the revisions of a single work item page by page
+func (c *AzureClient) getRevisions(id int) ([]WorkItem, error) {
+	var revisions []WorkItem
+	for {
+		apiURL := fmt.Sprintf("%s/%s/%s/_apis/wit/workItems/%d/revisions?$top=%d&$skip=%d&$expand=relations&api-version=7.0",
+			c.BaseURL,
+			url.PathEscape(c.Organization),
+			url.PathEscape(c.Project),
+			id,
+			maxRevisionsPage,
+			len(revisions))
+
+		req, err := http.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		c.setHeaders(req)
+
+		var page revisionsResponse
+		if err := c.do(req, &page); err != nil {
+			return nil, err
+		}
+
+		for _, rev := range page.Value {
+			revisions = append(revisions, c.convertToWorkItem(rev))
+		}
+		if len(page.Value) < maxRevisionsPage {
+			return revisions, nil
+		}
+	}
+}
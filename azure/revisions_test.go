@@ -0,0 +1,48 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestGetRevisions checks that revisions are fetched page by page for each work item
+func TestGetRevisions(t *testing.T) {
+	const total = maxRevisionsPage + 5
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/project/_apis/wit/workItems/42/revisions" {
+			http.NotFound(w, r)
+			return
+		}
+		skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+		var revs []string
+		for rev := skip + 1; rev <= min(skip+maxRevisionsPage, total); rev++ {
+			revs = append(revs, fmt.Sprintf(`{"id": 42, "rev": %d, "fields": {"System.State": "Active", "Microsoft.VSTS.Scheduling.RemainingWork": %d}}`, rev, total-rev))
+		}
+		fmt.Fprintf(w, `{"count": %d, "value": [%s]}`, len(revs), strings.Join(revs, ","))
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	revisions, err := client.GetRevisions([]int{42})
+	if err != nil {
+		t.Fatalf("GetRevisions failed: %v", err)
+	}
+	revs := revisions[42]
+	if len(revs) != total {
+		t.Fatalf("got %d revisions, want %d", len(revs), total)
+	}
+	last := revs[total-1]
+	if last.Rev != total || last.State != "Active" || last.RemainingWork != 0 || revs[0].RemainingWork != total-1 {
+		t.Errorf("revisions run from %+v to %+v", revs[0], last)
+	}
+
+	if _, err := client.GetRevisions([]int{7}); err == nil {
+		t.Error("expected an error for a missing work item")
+	}
+}
@@ -0,0 +1,56 @@
+package azure
+
+// WorkItemService is the set of Azure DevOps operations the application relies on.
+// It is implemented by AzureClient for live data and by MockAzureClient for offline use.
+type WorkItemService interface {
+	// QueryWorkItems returns the work items matching the given parameters
+	QueryWorkItems(params QueryParams) ([]WorkItem, error)
+	// GetWorkItem returns a single work item by ID with all of its fields and links
+	GetWorkItem(id int) (*WorkItem, error)
+	// GetWorkItems returns the ID, type, title and state of several work items
+	GetWorkItems(ids []int) ([]WorkItem, error)
+	// WorkItemURL returns the URL that identifies a work item as the target of links
+	WorkItemURL(id int) string
+	// CreateWorkItem creates a work item of the given type from patch operations
+	CreateWorkItem(itemType WorkItemType, ops []PatchOperation) (*WorkItem, error)
+	// UpdateWorkItem applies patch operations to a work item at the given revision
+	UpdateWorkItem(id, rev int, ops []PatchOperation) (*WorkItem, error)
+	// GetRevisions returns every revision of the given work items, oldest first
+	GetRevisions(ids []int) (map[int][]WorkItem, error)
+	// GetUpdates returns the changes made by each update of a work item, oldest first
+	GetUpdates(id int) ([]Update, error)
+
+	// GetComments returns the discussion of a work item, oldest first
+	GetComments(id int) ([]Comment, error)
+	// AddComment posts a new comment, written as plain text, to the discussion of a work item
+	AddComment(id int, text string) (*Comment, error)
+
+	// GetWorkItemTypes returns the work item types available in the project
+	GetWorkItemTypes() ([]WorkItemType, error)
+	// GetWorkflow returns the states and transitions of a work item type
+	GetWorkflow(itemType WorkItemType) (*Workflow, error)
+
+	// GetClassificationNodes returns the area or iteration tree of the project
+	GetClassificationNodes(group ClassificationGroup) (*ClassificationNode, error)
+	// GetCurrentIteration returns the iteration the team is currently working in
+	GetCurrentIteration() (*ClassificationNode, error)
+	// GetTeamAreas returns the area paths owned by the team
+	GetTeamAreas() ([]TeamArea, error)
+	// GetCapacity returns the capacity and days off of the team in an iteration
+	GetCapacity(iteration *ClassificationNode) (*Capacity, error)
+
+	// GetBoards returns the names of the team's boards
+	GetBoards() ([]string, error)
+	// GetBoard returns the columns, swimlanes and fields of one of the team's boards
+	GetBoard(name string) (*Board, error)
+
+	// GetTeamMembers returns the members of the team
+	GetTeamMembers() ([]Identity, error)
+	// SearchIdentities returns the users whose name or sign-in address starts with the query
+	SearchIdentities(query string) ([]Identity, error)
+}
+
+var (
+	_ WorkItemService = (*AzureClient)(nil)
+	_ WorkItemService = (*MockAzureClient)(nil)
+)
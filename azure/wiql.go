@@ -0,0 +1,258 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FieldTeamProject is the field holding the project a work item belongs to
+const FieldTeamProject = "System.TeamProject"
+
+// Clause is a condition in the WHERE part of a WIQL query
+type Clause interface {
+	wiql() string
+}
+
+// Macro is a WIQL macro such as @Me or @Today, emitted without quoting
+type Macro string
+
+const (
+	MacroMe               Macro = "@Me"
+	MacroToday            Macro = "@Today"
+	MacroProject          Macro = "@Project"
+	MacroCurrentIteration Macro = "@CurrentIteration"
+)
+
+// Today returns the @Today macro shifted by the given number of days
+func Today(offset int) Macro {
+	switch {
+	case offset > 0:
+		return Macro(fmt.Sprintf("%s + %d", MacroToday, offset))
+	case offset < 0:
+		return Macro(fmt.Sprintf("%s - %d", MacroToday, -offset))
+	default:
+		return MacroToday
+	}
+}
+
+// comparison compares a field with a single value
+type comparison struct {
+	field    string
+	operator string
+	value    any
+}
+
+func (c comparison) wiql() string {
+	return fmt.Sprintf("%s %s %s", fieldName(c.field), c.operator, literal(c.value))
+}
+
+// Equals matches work items whose field equals value
+func Equals(field string, value any) Clause {
+	return comparison{field, "=", value}
+}
+
+// NotEquals matches work items whose field does not equal value
+func NotEquals(field string, value any) Clause {
+	return comparison{field, "<>", value}
+}
+
+// Under matches work items whose area or iteration path is path or one of its children
+func Under(field, path string) Clause {
+	return comparison{field, "UNDER", path}
+}
+
+// Contains matches work items whose field contains value, e.g. a tag
+func Contains(field, value string) Clause {
+	return comparison{field, "CONTAINS", value}
+}
+
+// NotContains matches work items whose field does not contain value
+func NotContains(field, value string) Clause {
+	return comparison{field, "NOT CONTAINS", value}
+}
+
+// Ever matches work items whose field has had value at any revision
+func Ever(field string, value any) Clause {
+	return comparison{field, "EVER", value}
+}
+
+// Before matches work items whose date field is before value
+func Before(field string, value any) Clause {
+	return comparison{field, "<", value}
+}
+
+// OnOrBefore matches work items whose date field is on or before value
+func OnOrBefore(field string, value any) Clause {
+	return comparison{field, "<=", value}
+}
+
+// After matches work items whose date field is after value
+func After(field string, value any) Clause {
+	return comparison{field, ">", value}
+}
+
+// OnOrAfter matches work items whose date field is on or after value
+func OnOrAfter(field string, value any) Clause {
+	return comparison{field, ">=", value}
+}
+
+// inClause matches a field against a list of values
+type inClause struct {
+	field  string
+	values []any
+	not    bool
+}
+
+func (c inClause) wiql() string {
+	values := make([]string, len(c.values))
+	for i, v := range c.values {
+		values[i] = literal(v)
+	}
+	operator := "IN"
+	if c.not {
+		operator = "NOT IN"
+	}
+	return fmt.Sprintf("%s %s (%s)", fieldName(c.field), operator, strings.Join(values, ", "))
+}
+
+// In matches work items whose field equals any of values
+func In[T any](field string, values ...T) Clause {
+	return inClause{field: field, values: anySlice(values)}
+}
+
+// NotIn matches work items whose field equals none of values
+func NotIn[T any](field string, values ...T) Clause {
+	return inClause{field: field, values: anySlice(values), not: true}
+}
+
+// group combines clauses with AND or OR
+type group struct {
+	operator string
+	clauses  []Clause
+}
+
+func (g group) wiql() string {
+	if len(g.clauses) == 1 {
+		return g.clauses[0].wiql()
+	}
+	return "(" + g.join() + ")"
+}
+
+func (g group) join() string {
+	parts := make([]string, len(g.clauses))
+	for i, c := range g.clauses {
+		parts[i] = c.wiql()
+	}
+	return strings.Join(parts, " "+g.operator+" ")
+}
+
+// And matches work items that satisfy all clauses; nil clauses are ignored
+func And(clauses ...Clause) Clause {
+	return newGroup("AND", clauses)
+}
+
+// Or matches work items that satisfy any of the clauses; nil clauses are ignored
+func Or(clauses ...Clause) Clause {
+	return newGroup("OR", clauses)
+}
+
+func newGroup(operator string, clauses []Clause) Clause {
+	g := group{operator: operator}
+	for _, c := range clauses {
+		if c != nil {
+			g.clauses = append(g.clauses, c)
+		}
+	}
+	if len(g.clauses) == 0 {
+		return nil
+	}
+	return g
+}
+
+// Query is a WIQL query over work items
+type Query struct {
+	Fields  []string
+	Where   Clause
+	OrderBy []SortKey
+}
+
+// String returns the WIQL text of the query
+func (q Query) String() string {
+	fields := q.Fields
+	if len(fields) == 0 {
+		fields = []string{FieldID}
+	}
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = fieldName(f)
+	}
+
+	var s strings.Builder
+	s.WriteString("SELECT ")
+	s.WriteString(strings.Join(names, ", "))
+	s.WriteString(" FROM WorkItems")
+
+	if q.Where != nil {
+		s.WriteString(" WHERE ")
+		if g, ok := q.Where.(group); ok {
+			s.WriteString(g.join())
+		} else {
+			s.WriteString(q.Where.wiql())
+		}
+	}
+
+	if len(q.OrderBy) > 0 {
+		keys := make([]string, len(q.OrderBy))
+		for i, key := range q.OrderBy {
+			direction := "ASC"
+			if key.Descending {
+				direction = "DESC"
+			}
+			keys[i] = fieldName(string(key.Field)) + " " + direction
+		}
+		s.WriteString(" ORDER BY ")
+		s.WriteString(strings.Join(keys, ", "))
+	}
+
+	return s.String()
+}
+
+// fieldName returns the bracketed form of a field reference name
+func fieldName(field string) string {
+	return "[" + strings.NewReplacer("[", "", "]", "").Replace(field) + "]"
+}
+
+// literal formats a value as a WIQL literal, escaping quotes in strings
+func literal(value any) string {
+	switch v := value.(type) {
+	case Macro:
+		return string(v)
+	case string:
+		return quote(v)
+	case WorkItemType:
+		return quote(string(v))
+	case time.Time:
+		return quote(v.Format("2006-01-02"))
+	case int, int64, float64:
+		return fmt.Sprint(v)
+	case bool:
+		return fmt.Sprint(v)
+	default:
+		return quote(fmt.Sprint(v))
+	}
+}
+
+// quote returns s as a single-quoted WIQL string literal
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// anySlice converts a typed slice to a slice of values
+func anySlice[T any](values []T) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+package azure
+
+import (
+	"testing"
+	"time"
+)
+
+// TestClauses checks the WIQL text generated for each kind of clause
+func TestClauses(t *testing.T) {
+	tests := []struct {
+		name   string
+		clause Clause
+		want   string
+	}{
+		{"equals", Equals(FieldState, "Active"), "[System.State] = 'Active'"},
+		{"escapes quotes", Equals(FieldAssignedTo, "Conan O'Brien"), "[System.AssignedTo] = 'Conan O''Brien'"},
+		{"not equals", NotEquals(FieldPriority, 1), "[Microsoft.VSTS.Common.Priority] <> 1"},
+		{"in", In(FieldState, "New", "Active"), "[System.State] IN ('New', 'Active')"},
+		{"not in", NotIn(FieldWorkItemType, Task, Bug), "[System.WorkItemType] NOT IN ('Task', 'Bug')"},
+		{"under", Under(FieldAreaPath, `Fazure\Team's Area`), `[System.AreaPath] UNDER 'Fazure\Team''s Area'`},
+		{"contains", Contains(FieldTags, "ui"), "[System.Tags] CONTAINS 'ui'"},
+		{"not contains", NotContains(FieldTitle, "'quoted'"), "[System.Title] NOT CONTAINS '''quoted'''"},
+		{"ever", Ever(FieldAssignedTo, MacroMe), "[System.AssignedTo] EVER @Me"},
+		{"today", OnOrAfter(FieldChangedDate, Today(-7)), "[System.ChangedDate] >= @Today - 7"},
+		{"today plus", Before(FieldChangedDate, Today(2)), "[System.ChangedDate] < @Today + 2"},
+		{"date", After(FieldCreatedDate, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)), "[System.CreatedDate] > '2024-01-15'"},
+		{"on or before", OnOrBefore(FieldCreatedDate, MacroToday), "[System.CreatedDate] <= @Today"},
+		{"single group", And(Equals(FieldState, "New")), "[System.State] = 'New'"},
+		{
+			"nested groups",
+			And(Equals(FieldState, "New"), Or(Contains(FieldTags, "a"), Contains(FieldTags, "b"))),
+			"([System.State] = 'New' AND ([System.Tags] CONTAINS 'a' OR [System.Tags] CONTAINS 'b'))",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.clause.wiql(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestQueryString checks the complete WIQL text of a query
+func TestQueryString(t *testing.T) {
+	query := Query{
+		Where: And(
+			Equals(FieldTeamProject, MacroProject),
+			nil,
+			Equals(FieldAssignedTo, "O'Brien"),
+		),
+		OrderBy: []SortKey{{Field: SortByPriority}, {Field: SortByChangedDate, Descending: true}},
+	}
+
+	want := "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @Project AND [System.AssignedTo] = 'O''Brien'" +
+		" ORDER BY [Microsoft.VSTS.Common.Priority] ASC, [System.ChangedDate] DESC"
+	if got := query.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got := (Query{}).String(); got != "SELECT [System.Id] FROM WorkItems" {
+		t.Errorf("empty query: got %q", got)
+	}
+}
+
+// TestBuildWIQL checks that query parameters are escaped in the generated WIQL
+func TestBuildWIQL(t *testing.T) {
+	client := NewClient("org", "Team's Project", "pat")
+
+	got := client.buildWIQL(QueryParams{
+		AssignedTo:    "Conan O'Brien",
+		States:        []string{"New", "Active"},
+		ExcludeStates: TerminalStates,
+		Types:         []WorkItemType{UserStory, Bug},
+		Tags:          []string{"customer's"},
+		ExcludeTags:   []string{"blocked"},
+		AreaPath:      `Team's Project\Web`,
+	})
+	want := "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = 'Team''s Project'" +
+		" AND [System.AssignedTo] = 'Conan O''Brien'" +
+		" AND [System.State] IN ('New', 'Active')" +
+		" AND [System.State] NOT IN ('Done', 'Closed', 'Removed')" +
+		" AND [System.WorkItemType] IN ('User Story', 'Bug')" +
+		" AND [System.Tags] CONTAINS 'customer''s'" +
+		" AND [System.Tags] NOT CONTAINS 'blocked'" +
+		` AND [System.AreaPath] UNDER 'Team''s Project\Web'`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestBuildWIQLTeamScope checks the clauses that scope a query to the team's areas
+// and to recently finished work items
+func TestBuildWIQLTeamScope(t *testing.T) {
+	client := NewClient("org", "Fazure", "pat")
+
+	got := client.buildWIQL(QueryParams{
+		Areas:         []TeamArea{{Path: `Fazure\Web`, IncludeChildren: true}, {Path: "Fazure"}},
+		FinishedSince: time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC),
+	})
+	want := "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = 'Fazure'" +
+		` AND ([System.AreaPath] UNDER 'Fazure\Web' OR [System.AreaPath] = 'Fazure')` +
+		" AND ([System.State] NOT IN ('Done', 'Closed', 'Removed') OR [System.ChangedDate] >= '2024-03-01')"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
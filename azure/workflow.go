@@ -0,0 +1,131 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+// State categories group the states of a workflow by how far along work is
+const (
+	CategoryProposed   = "Proposed"
+	CategoryInProgress = "InProgress"
+	CategoryResolved   = "Resolved"
+	CategoryCompleted  = "Completed"
+	CategoryRemoved    = "Removed"
+)
+
+// State is a state of a work item type's workflow
+type State struct {
+	Name     string
+	Category string
+	// Color is the hex RGB color of the state, without a leading #
+	Color string
+}
+
+// Workflow describes the states of a work item type and the transitions between them
+type Workflow struct {
+	Type   WorkItemType
+	States []State
+	// Transitions maps each state to the states it can move to. The empty state
+	// lists the states a new work item can start in.
+	Transitions map[string][]string
+}
+
+// StateNames returns the names of the states in workflow order
+func (w *Workflow) StateNames() []string {
+	names := make([]string, len(w.States))
+	for i, s := range w.States {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// CanMove reports whether a work item can move from one state to another
+func (w *Workflow) CanMove(from, to string) bool {
+	if from == to {
+		return true
+	}
+	allowed, ok := w.Transitions[from]
+	if !ok {
+		// Without transition rules any state of the workflow may follow
+		return slices.ContainsFunc(w.States, func(s State) bool { return s.Name == to })
+	}
+	return slices.Contains(allowed, to)
+}
+
+// Next returns the state a work item in the given state advances to: the first
+// state after it in workflow order that it can move to, skipping removal
+func (w *Workflow) Next(state string) (string, bool) {
+	current := slices.IndexFunc(w.States, func(s State) bool { return s.Name == state })
+	if current < 0 {
+		return "", false
+	}
+	for _, s := range w.States[current+1:] {
+		if s.Category != CategoryRemoved && w.CanMove(state, s.Name) {
+			return s.Name, true
+		}
+	}
+	return "", false
+}
+
+// workItemTypeResponse represents a single work item type returned by the API
+type workItemTypeResponse struct {
+	Name   string `json:"name"`
+	States []struct {
+		Name     string `json:"name"`
+		Color    string `json:"color"`
+		Category string `json:"category"`
+	} `json:"states"`
+	Transitions map[string][]struct {
+		To string `json:"to"`
+	} `json:"transitions"`
+}
+
+// GetWorkflow returns the states and transitions of a work item type.
+// Workflows are cached for the lifetime of the client.
+func (c *AzureClient) GetWorkflow(itemType WorkItemType) (*Workflow, error) {
+	c.mu.Lock()
+	cached, ok := c.workflows[itemType]
+	c.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s/_apis/wit/workitemtypes/%s?api-version=7.0",
+		c.BaseURL,
+		url.PathEscape(c.Organization),
+		url.PathEscape(c.Project),
+		url.PathEscape(string(itemType)))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	var typeResp workItemTypeResponse
+	if err := c.do(req, &typeResp); err != nil {
+		return nil, err
+	}
+
+	workflow := &Workflow{
+		Type:        itemType,
+		Transitions: make(map[string][]string, len(typeResp.Transitions)),
+	}
+	for _, s := range typeResp.States {
+		workflow.States = append(workflow.States, State{Name: s.Name, Category: s.Category, Color: s.Color})
+	}
+	for from, transitions := range typeResp.Transitions {
+		for _, t := range transitions {
+			workflow.Transitions[from] = append(workflow.Transitions[from], t.To)
+		}
+	}
+
+	c.mu.Lock()
+	c.workflows[itemType] = workflow
+	c.mu.Unlock()
+	return workflow, nil
+}
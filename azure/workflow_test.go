@@ -0,0 +1,80 @@
+package azure
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// TestGetWorkflow checks that states and transitions are read from the work item
+// type and that the workflow is fetched only once
+func TestGetWorkflow(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/project/_apis/wit/workitemtypes/User Story" {
+			http.NotFound(w, r)
+			return
+		}
+		requests++
+		json.NewEncoder(w).Encode(map[string]any{
+			"name": "User Story",
+			"states": []map[string]string{
+				{"name": "New", "color": "b2b2b2", "category": "Proposed"},
+				{"name": "Active", "color": "007acc", "category": "InProgress"},
+				{"name": "Closed", "color": "339933", "category": "Completed"},
+			},
+			"transitions": map[string][]map[string]string{
+				"":       {{"to": "New"}},
+				"New":    {{"to": "Active"}},
+				"Active": {{"to": "Closed"}, {"to": "New"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("org", "project", "pat")
+	client.BaseURL = server.URL
+
+	for range 2 {
+		workflow, err := client.GetWorkflow(UserStory)
+		if err != nil {
+			t.Fatalf("GetWorkflow failed: %v", err)
+		}
+		if got := workflow.StateNames(); !slices.Equal(got, []string{"New", "Active", "Closed"}) {
+			t.Errorf("states = %v, want [New Active Closed]", got)
+		}
+		if !workflow.CanMove("Active", "New") || workflow.CanMove("New", "Closed") {
+			t.Errorf("transitions = %v", workflow.Transitions)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("fetched the workflow %d times, want 1", requests)
+	}
+}
+
+// TestWorkflowNext checks which state work items advance to
+func TestWorkflowNext(t *testing.T) {
+	workflow := mockWorkflows()[UserStory]
+
+	tests := []struct {
+		state string
+		want  string
+		ok    bool
+	}{
+		{"New", "Active", true},
+		{"Active", "In Progress", true},
+		{"Resolved", "Closed", true},
+		{"Closed", "", false},
+		{"Removed", "", false},
+		{"Unknown", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := workflow.Next(tt.state)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Next(%q) = %q, %v; want %q, %v", tt.state, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -1,5 +1,12 @@
 package azure
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
 type WorkItemType string
 
 const (
@@ -10,26 +17,205 @@ const (
 	Bug         WorkItemType = "Bug"
 )
 
+// TerminalStates are the states in which work items of the built-in processes
+// are finished or removed
+var TerminalStates = []string{"Done", "Closed", "Removed"}
+
+// Field reference names used when reading and writing work items
+const (
+	FieldID                 = "System.Id"
+	FieldRev                = "System.Rev"
+	FieldWorkItemType       = "System.WorkItemType"
+	FieldTitle              = "System.Title"
+	FieldAssignedTo         = "System.AssignedTo"
+	FieldState              = "System.State"
+	FieldPriority           = "Microsoft.VSTS.Common.Priority"
+	FieldDescription        = "System.Description"
+	FieldAcceptanceCriteria = "Microsoft.VSTS.Common.AcceptanceCriteria"
+	FieldCreatedBy          = "System.CreatedBy"
+	FieldCreatedDate        = "System.CreatedDate"
+	FieldChangedDate        = "System.ChangedDate"
+	FieldChangedBy          = "System.ChangedBy"
+	FieldStackRank          = "Microsoft.VSTS.Common.StackRank"
+	FieldBacklogPriority    = "Microsoft.VSTS.Common.BacklogPriority"
+	FieldTags               = "System.Tags"
+	FieldAreaPath           = "System.AreaPath"
+	FieldIterationPath      = "System.IterationPath"
+	FieldStoryPoints        = "Microsoft.VSTS.Scheduling.StoryPoints"
+	FieldOriginalEstimate   = "Microsoft.VSTS.Scheduling.OriginalEstimate"
+	FieldRemainingWork      = "Microsoft.VSTS.Scheduling.RemainingWork"
+	FieldCompletedWork      = "Microsoft.VSTS.Scheduling.CompletedWork"
+)
+
+// Link types of relations between work items
+const (
+	RelParent      = "System.LinkTypes.Hierarchy-Reverse"
+	RelChild       = "System.LinkTypes.Hierarchy-Forward"
+	RelRelated     = "System.LinkTypes.Related"
+	RelPredecessor = "System.LinkTypes.Dependency-Reverse"
+	RelSuccessor   = "System.LinkTypes.Dependency-Forward"
+	// RelArtifact links to commits, pull requests, branches and builds
+	RelArtifact  = "ArtifactLink"
+	RelHyperlink = "Hyperlink"
+)
+
+// ReverseRel returns the link type the target of a link has back to its source,
+// or "" for links that are not mirrored on the target
+func ReverseRel(rel string) string {
+	switch rel {
+	case RelParent:
+		return RelChild
+	case RelChild:
+		return RelParent
+	case RelRelated:
+		return RelRelated
+	case RelPredecessor:
+		return RelSuccessor
+	case RelSuccessor:
+		return RelPredecessor
+	default:
+		return ""
+	}
+}
+
+// Relation is a link from a work item to another work item or an external resource
+type Relation struct {
+	Rel string
+	URL string
+	// TargetID is the ID of the linked work item, or 0 for links to other resources
+	TargetID int
+	Name     string
+	Comment  string
+}
+
 type Comment struct {
+	ID      int
 	Author  string
 	Date    string
-	Content string
+	Content string // HTML
 }
 
 // WorkItem represents a work item from Azure DevOps
 type WorkItem struct {
-	ID                 int
-	Type               WorkItemType
-	Title              string
-	AssignedTo         string
+	ID         int
+	Rev        int
+	Type       WorkItemType
+	Title      string
+	AssignedTo string
+	// Assignee is the identity AssignedTo is the display name of
+	Assignee           Identity
 	State              string
 	Priority           int
 	Description        string
 	AcceptanceCriteria string
 	CreatedBy          string
 	CreatedDate        string
+	ChangedDate        string
 	Tags               []string
 	AreaPath           string
 	Iteration          string
 	Comments           []Comment
+	Relations          []Relation
+	// StoryPoints, OriginalEstimate, RemainingWork and CompletedWork are the
+	// scheduling fields, zero when unset. Work is measured in hours.
+	StoryPoints      float64
+	OriginalEstimate float64
+	RemainingWork    float64
+	CompletedWork    float64
+	// Fields holds the raw values of the fetched fields by reference name
+	Fields map[string]any
+}
+
+// Field returns the display value of the field with the given reference name
+func (wi WorkItem) Field(ref string) string {
+	switch ref {
+	case FieldID:
+		return strconv.Itoa(wi.ID)
+	case FieldRev:
+		return strconv.Itoa(wi.Rev)
+	case FieldWorkItemType:
+		return string(wi.Type)
+	case FieldTitle:
+		return wi.Title
+	case FieldAssignedTo:
+		return wi.AssignedTo
+	case FieldState:
+		return wi.State
+	case FieldPriority:
+		if wi.Priority == 0 {
+			return ""
+		}
+		return strconv.Itoa(wi.Priority)
+	case FieldDescription:
+		return wi.Description
+	case FieldAcceptanceCriteria:
+		return wi.AcceptanceCriteria
+	case FieldCreatedBy:
+		return wi.CreatedBy
+	case FieldCreatedDate:
+		return wi.CreatedDate
+	case FieldChangedDate:
+		return wi.ChangedDate
+	case FieldTags:
+		return strings.Join(wi.Tags, "; ")
+	case FieldAreaPath:
+		return wi.AreaPath
+	case FieldIterationPath:
+		return wi.Iteration
+	default:
+		return formatFieldValue(wi.Fields[ref])
+	}
+}
+
+// Changed returns the time of the last change to the work item, or the zero time
+// if its changed date is missing
+func (wi WorkItem) Changed() time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, wi.ChangedDate, time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// ParentID returns the ID of the parent work item, or 0 if it has none
+func (wi WorkItem) ParentID() int {
+	for _, rel := range wi.Relations {
+		if rel.Rel == RelParent {
+			return rel.TargetID
+		}
+	}
+	return 0
+}
+
+// ChildIDs returns the IDs of the child work items
+func (wi WorkItem) ChildIDs() []int {
+	var ids []int
+	for _, rel := range wi.Relations {
+		if rel.Rel == RelChild {
+			ids = append(ids, rel.TargetID)
+		}
+	}
+	return ids
+}
+
+// formatFieldValue returns the display value of a raw field value
+func formatFieldValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t.Local().Format("2006-01-02 15:04")
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]any:
+		// Identity fields
+		if name, ok := v["displayName"].(string); ok {
+			return name
+		}
+	}
+	return fmt.Sprint(value)
 }
@@ -0,0 +1,77 @@
+// Package config loads and saves the fazure settings file.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Column is a backlog table column showing a work item field
+type Column struct {
+	// Field is the reference name of the field, e.g. Microsoft.VSTS.Scheduling.StoryPoints
+	Field string `json:"field"`
+	// Title is the column header; the field's label is used when empty
+	Title string `json:"title,omitempty"`
+}
+
+// Config holds the user's settings
+type Config struct {
+	// Columns are the backlog table columns in display order; the default columns are used when empty
+	Columns []Column `json:"columns,omitempty"`
+}
+
+// Path returns the location of the settings file
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config directory: %w", err)
+	}
+	return filepath.Join(dir, "fazure", "config.json"), nil
+}
+
+// Load reads the settings file, returning empty settings if it does not exist
+func Load() (Config, error) {
+	var cfg Config
+	path, err := Path()
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save writes the settings file, creating its directory if needed
+func (c Config) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
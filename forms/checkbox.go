@@ -0,0 +1,155 @@
+package forms
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var checkboxLabelStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("15"))
+
+// CheckboxField implements FormField for selecting any number of options.
+type CheckboxField struct {
+	label   string
+	focused bool
+	editing bool
+	options []string
+	checked []bool
+	saved   []bool
+	cursor  int
+}
+
+func NewCheckboxField(label string, options []string, checked []string) *CheckboxField {
+	c := &CheckboxField{
+		label:   label,
+		options: options,
+		checked: make([]bool, len(options)),
+	}
+	for i, opt := range options {
+		c.checked[i] = slices.Contains(checked, opt)
+	}
+	return c
+}
+
+func (c *CheckboxField) Label() string {
+	return c.label
+}
+
+// Checked returns the selected options.
+func (c *CheckboxField) Checked() []string {
+	var checked []string
+	for i, opt := range c.options {
+		if c.checked[i] {
+			checked = append(checked, opt)
+		}
+	}
+	return checked
+}
+
+// Unchecked returns the options that are not selected.
+func (c *CheckboxField) Unchecked() []string {
+	var unchecked []string
+	for i, opt := range c.options {
+		if !c.checked[i] {
+			unchecked = append(unchecked, opt)
+		}
+	}
+	return unchecked
+}
+
+// Value returns the selected options as a comma separated list.
+func (c *CheckboxField) Value() string {
+	return strings.Join(c.Checked(), ", ")
+}
+
+func (c *CheckboxField) Update(form *Form, msg tea.Msg) tea.Cmd {
+	if !c.editing {
+		return nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			if c.cursor < len(c.options)-1 {
+				c.cursor++
+			}
+		case "k", "up":
+			if c.cursor > 0 {
+				c.cursor--
+			}
+		case " ", "x":
+			c.checked[c.cursor] = !c.checked[c.cursor]
+		case "a":
+			all := !slices.Contains(c.checked, false)
+			for i := range c.checked {
+				c.checked[i] = !all
+			}
+		}
+	}
+	return nil
+}
+
+func (c *CheckboxField) View(form *Form) string {
+	label := form.Pad(c.label + ":")
+
+	if !c.editing {
+		value := c.Value()
+		switch {
+		case !slices.Contains(c.checked, false):
+			value = "All"
+		case value == "":
+			value = "None"
+		}
+		if c.focused {
+			return checkboxLabelStyle.Render(label + value)
+		}
+		return label + value
+	}
+
+	all := "\n"
+	for i, opt := range c.options {
+		cursor := "  "
+		if i == c.cursor {
+			cursor = "▶ "
+		}
+		if c.checked[i] {
+			all += cursor + "[x] " + opt + "\n"
+		} else {
+			all += cursor + "[ ] " + opt + "\n"
+		}
+	}
+	return checkboxLabelStyle.Render(label) + all
+}
+
+func (c *CheckboxField) Focus() tea.Cmd {
+	c.focused = true
+	return nil
+}
+
+func (c *CheckboxField) Blur() {
+	c.focused = false
+}
+
+func (c *CheckboxField) Edit() tea.Cmd {
+	c.editing = true
+	c.saved = slices.Clone(c.checked)
+	return nil
+}
+
+func (c *CheckboxField) Save() {
+	c.editing = false
+}
+
+func (c *CheckboxField) Cancel() {
+	c.editing = false
+	c.checked = c.saved
+}
+
+func (c *CheckboxField) Terminator() string {
+	return "enter"
+}
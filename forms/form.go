@@ -18,6 +18,7 @@ type FormField interface {
 
 	Edit() tea.Cmd
 	Save()
+	Cancel()
 }
 
 type Form struct {
@@ -26,6 +27,10 @@ type Form struct {
 	focusedIndex int
 	IsEditing    bool
 	labelPad     int
+
+	// OnSave is called after a field has been saved, allowing the owner
+	// of the form to persist the new value.
+	OnSave func(field FormField) tea.Cmd
 }
 
 func NewForm(fields ...FormField) *Form {
@@ -52,12 +57,12 @@ func (f *Form) Update(model tea.Model, msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "esc":
 				f.IsEditing = false
-				f.fields[f.focusedIndex].Save()
+				f.fields[f.focusedIndex].Cancel()
 				return model, nil
 			case f.fields[f.focusedIndex].Terminator():
 				f.IsEditing = false
 				f.fields[f.focusedIndex].Save()
-				return model, nil
+				return model, f.saved(f.fields[f.focusedIndex])
 			default:
 				return model, f.fields[f.focusedIndex].Update(f, msg)
 			}
@@ -92,6 +97,13 @@ func (f *Form) View() string {
 	return output
 }
 
+func (f *Form) saved(field FormField) tea.Cmd {
+	if f.OnSave == nil {
+		return nil
+	}
+	return f.OnSave(field)
+}
+
 func (f *Form) focusPrev() tea.Cmd {
 	if f.focusedIndex > 0 {
 		f.fields[f.focusedIndex].Blur()
@@ -17,6 +17,7 @@ type RadioField struct {
 	editing       bool
 	options       []string
 	selectedIndex int
+	savedIndex    int
 	horizontal    bool
 }
 
@@ -32,6 +33,28 @@ func (r *RadioField) Label() string {
 	return r.label
 }
 
+// Value returns the currently selected option.
+func (r *RadioField) Value() string {
+	return r.options[r.selectedIndex]
+}
+
+// Select selects the given option, adding it to the options if it is not present.
+func (r *RadioField) Select(option string) {
+	index := -1
+	for i, opt := range r.options {
+		if opt == option {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		r.options = append(r.options, option)
+		index = len(r.options) - 1
+	}
+	r.selectedIndex = index
+	r.savedIndex = index
+}
+
 func (r *RadioField) Update(form *Form, msg tea.Msg) tea.Cmd {
 	if r.horizontal || !r.editing {
 		return r.updateHorizontal(msg)
@@ -156,6 +179,7 @@ func (r *RadioField) Blur() {
 
 func (r *RadioField) Edit() tea.Cmd {
 	r.editing = true
+	r.savedIndex = r.selectedIndex
 	return nil
 }
 
@@ -163,6 +187,11 @@ func (r *RadioField) Save() {
 	r.editing = false
 }
 
+func (r *RadioField) Cancel() {
+	r.editing = false
+	r.selectedIndex = r.savedIndex
+}
+
 func (r *RadioField) Terminator() string {
 	return "enter"
 }
@@ -59,3 +59,5 @@ func (r *Readonly) Edit() tea.Cmd {
 func (r *Readonly) Save() {
 }
 
+func (r *Readonly) Cancel() {
+}
@@ -0,0 +1,191 @@
+package forms
+
+import (
+	"fazure/markup"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RichTextField implements FormField for an HTML rich text field. It shows the text
+// formatted for the terminal, and is edited as Markdown or as plain text, which is
+// converted back to HTML when saved.
+type RichTextField struct {
+	label    string
+	html     string
+	focused  bool
+	editing  bool
+	textarea textarea.Model
+	// plain is set when editing plain text rather than Markdown
+	plain bool
+	// source is the text the editor started with, and dirty is set once text has
+	// been converted in the editor, so unchanged text keeps its original HTML
+	source string
+	dirty  bool
+	// offset is the first line shown when reading
+	offset int
+}
+
+func NewRichTextField(label string, html string) *RichTextField {
+	ta := textarea.New()
+	ta.SetWidth(50)
+	ta.SetHeight(10)
+	ta.ShowLineNumbers = false
+
+	return &RichTextField{
+		label:    label,
+		html:     html,
+		textarea: ta,
+	}
+}
+
+func (r *RichTextField) Label() string {
+	return r.label
+}
+
+// Value returns the HTML of the field.
+func (r *RichTextField) Value() string {
+	return r.html
+}
+
+// SetValue replaces the HTML of the field.
+func (r *RichTextField) SetValue(html string) {
+	r.html = html
+	r.offset = 0
+}
+
+// toText converts HTML to the text edited in the current mode
+func (r *RichTextField) toText(html string) string {
+	if r.plain {
+		return markup.ToPlain(html)
+	}
+	return markup.ToMarkdown(html)
+}
+
+// edited returns the HTML of the text in the editor
+func (r *RichTextField) edited() string {
+	text := r.textarea.Value()
+	switch {
+	case !r.dirty && text == r.source:
+		return r.html
+	case r.plain:
+		return markup.FromPlain(text)
+	default:
+		return markup.FromMarkdown(text)
+	}
+}
+
+// lines returns the formatted text
+func (r *RichTextField) lines() []string {
+	if strings.TrimSpace(r.html) == "" {
+		return nil
+	}
+	return strings.Split(markup.Render(r.html, r.textarea.Width()), "\n")
+}
+
+func (r *RichTextField) Update(form *Form, msg tea.Msg) tea.Cmd {
+	key, ok := msg.(tea.KeyMsg)
+	if r.editing {
+		if ok && key.String() == "ctrl+t" {
+			// Switching modes converts the text written so far
+			html := r.edited()
+			r.dirty = r.dirty || r.textarea.Value() != r.source
+			r.plain = !r.plain
+			r.source = r.toText(html)
+			r.textarea.SetValue(r.source)
+			return nil
+		}
+		var cmd tea.Cmd
+		r.textarea, cmd = r.textarea.Update(msg)
+		return cmd
+	}
+
+	if !ok {
+		return nil
+	}
+	page := r.textarea.Height() / 2
+	switch key.String() {
+	case "ctrl+d", "pgdown":
+		r.offset = max(min(r.offset+page, len(r.lines())-r.textarea.Height()), 0)
+	case "ctrl+u", "pgup":
+		r.offset = max(r.offset-page, 0)
+	}
+	return nil
+}
+
+func (r *RichTextField) View(form *Form) string {
+	output := ""
+	if r.label != "" {
+		output += r.label + ":"
+	}
+
+	if r.focused {
+		output = textareadLabelStyle.Render(output)
+	}
+
+	output += "\n\n"
+	if r.editing {
+		mode, other := "Markdown", "plain text"
+		if r.plain {
+			mode, other = "Plain text", "Markdown"
+		}
+		output += r.textarea.View()
+		helpText := textareadHelpStyle.Render(fmt.Sprintf("(%s • ctrl+t for %s • %s to save, esc to cancel)", mode, other, r.Terminator()))
+		return output + "\n" + helpText + "\n"
+	}
+
+	lines := r.lines()
+	if len(lines) == 0 {
+		output += textareadHelpStyle.Render("(empty)")
+	}
+	end := min(r.offset+r.textarea.Height(), len(lines))
+	output += strings.Join(lines[min(r.offset, end):end], "\n")
+
+	if r.focused {
+		helpText := "(Press enter to edit"
+		if len(lines) > r.textarea.Height() {
+			helpText += fmt.Sprintf(" • ctrl+d/ctrl+u to scroll, lines %d-%d of %d", r.offset+1, end, len(lines))
+		}
+		output += "\n" + textareadHelpStyle.Render(helpText+")") + "\n"
+	} else if end < len(lines) {
+		output += "\n" + textareadHelpStyle.Render(fmt.Sprintf("(%d more lines)", len(lines)-end)) + "\n"
+	}
+
+	return output
+}
+
+func (r *RichTextField) Focus() tea.Cmd {
+	r.focused = true
+	return nil
+}
+
+func (r *RichTextField) Blur() {
+	r.focused = false
+	r.textarea.Blur()
+}
+
+func (r *RichTextField) Edit() tea.Cmd {
+	r.editing = true
+	r.dirty = false
+	r.source = r.toText(r.html)
+	r.textarea.SetValue(r.source)
+	return r.textarea.Focus()
+}
+
+func (r *RichTextField) Save() {
+	r.html = r.edited()
+	r.editing = false
+	r.offset = 0
+	r.textarea.Blur()
+}
+
+func (r *RichTextField) Cancel() {
+	r.editing = false
+	r.textarea.Blur()
+}
+
+func (r *RichTextField) Terminator() string {
+	return "ctrl+s"
+}
@@ -48,7 +48,7 @@ func (t *Tabs) Update(form *Form, msg tea.Msg) tea.Cmd {
 			switch msg.String() {
 			case "esc":
 				t.isEditing = false
-				t.fields[t.focusedIndex].Save()
+				t.fields[t.focusedIndex].Cancel()
 				return nil
 			case t.fields[t.focusedIndex].Terminator():
 				t.isEditing = false
@@ -73,7 +73,7 @@ func (t *Tabs) Update(form *Form, msg tea.Msg) tea.Cmd {
 		}
 	}
 
-	return nil
+	return t.fields[t.focusedIndex].Update(form, msg)
 }
 
 func (t *Tabs) View(form *Form) string {
@@ -110,6 +110,11 @@ func (t *Tabs) Save() {
 	t.fields[t.focusedIndex].Save()
 }
 
+func (t *Tabs) Cancel() {
+	t.isEditing = false
+	t.fields[t.focusedIndex].Cancel()
+}
+
 func (t *Tabs) Terminator() string {
 	return t.fields[t.focusedIndex].Terminator()
 }
@@ -21,6 +21,7 @@ type TextAreaField struct {
 	editing    bool
 	textarea   textarea.Model
 	alwaysShow bool
+	saved      string
 }
 
 func NewTextAreaField(label string, content string, alwaysShow bool) *TextAreaField {
@@ -41,6 +42,16 @@ func (t *TextAreaField) Label() string {
 	return t.label
 }
 
+// Value returns the current content of the text area.
+func (t *TextAreaField) Value() string {
+	return t.textarea.Value()
+}
+
+// SetValue replaces the content of the text area.
+func (t *TextAreaField) SetValue(value string) {
+	t.textarea.SetValue(value)
+}
+
 func (t *TextAreaField) Update(form *Form, msg tea.Msg) tea.Cmd {
 	if t.editing {
 		var cmd tea.Cmd
@@ -87,6 +98,7 @@ func (t *TextAreaField) Blur() {
 
 func (t *TextAreaField) Edit() tea.Cmd {
 	t.editing = true
+	t.saved = t.textarea.Value()
 	return t.textarea.Focus()
 }
 
@@ -95,6 +107,12 @@ func (t *TextAreaField) Save() {
 	t.textarea.Blur()
 }
 
+func (t *TextAreaField) Cancel() {
+	t.editing = false
+	t.textarea.SetValue(t.saved)
+	t.textarea.Blur()
+}
+
 func (t *TextAreaField) Terminator() string {
 	return "ctrl+s"
 }
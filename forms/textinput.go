@@ -0,0 +1,104 @@
+package forms
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var textinputLabelStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("15"))
+
+// TextInputField implements FormField for a single line of text.
+type TextInputField struct {
+	label     string
+	focused   bool
+	editing   bool
+	textinput textinput.Model
+	saved     string
+}
+
+func NewTextInputField(label string, value string, placeholder string) *TextInputField {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.Width = 40
+	ti.Prompt = ""
+	ti.SetValue(value)
+
+	return &TextInputField{
+		label:     label,
+		textinput: ti,
+	}
+}
+
+func (t *TextInputField) Label() string {
+	return t.label
+}
+
+// Value returns the entered text.
+func (t *TextInputField) Value() string {
+	return t.textinput.Value()
+}
+
+// SetValue replaces the entered text.
+func (t *TextInputField) SetValue(value string) {
+	t.textinput.SetValue(value)
+}
+
+func (t *TextInputField) Update(form *Form, msg tea.Msg) tea.Cmd {
+	if t.editing {
+		var cmd tea.Cmd
+		t.textinput, cmd = t.textinput.Update(msg)
+		return cmd
+	}
+	return nil
+}
+
+func (t *TextInputField) View(form *Form) string {
+	label := form.Pad(t.label + ":")
+
+	if t.editing {
+		return textinputLabelStyle.Render(label) + t.textinput.View()
+	}
+
+	value := t.textinput.Value()
+	if value == "" {
+		value = t.textinput.Placeholder
+	}
+	if t.focused {
+		return textinputLabelStyle.Render(label + value)
+	}
+	return label + value
+}
+
+func (t *TextInputField) Focus() tea.Cmd {
+	t.focused = true
+	return nil
+}
+
+func (t *TextInputField) Blur() {
+	t.focused = false
+}
+
+func (t *TextInputField) Edit() tea.Cmd {
+	t.editing = true
+	t.saved = t.textinput.Value()
+	t.textinput.CursorEnd()
+	return t.textinput.Focus()
+}
+
+func (t *TextInputField) Save() {
+	t.editing = false
+	t.textinput.Blur()
+}
+
+func (t *TextInputField) Cancel() {
+	t.editing = false
+	t.textinput.SetValue(t.saved)
+	t.textinput.Blur()
+}
+
+func (t *TextInputField) Terminator() string {
+	return "enter"
+}
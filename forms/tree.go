@@ -0,0 +1,260 @@
+package forms
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	treeLabelStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("15"))
+
+	treeCursorStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("86"))
+
+	treeDetailStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241"))
+)
+
+// maxTreeRows limits how many options of a TreeField are listed at once
+const maxTreeRows = 10
+
+// TreeOption is a node of the tree a TreeField picks from.
+type TreeOption struct {
+	Label string
+	// Value is returned by the field once the option is chosen.
+	Value string
+	// Detail is shown dimmed after the label.
+	Detail   string
+	Children []*TreeOption
+}
+
+// treeRow is an option listed while the field is being edited
+type treeRow struct {
+	option *TreeOption
+	depth  int
+	parent int
+}
+
+// TreeField implements FormField for choosing a node of a tree, such as an
+// area or iteration path. Its options are usually set once they are loaded.
+type TreeField struct {
+	label       string
+	placeholder string
+	focused     bool
+	editing     bool
+	options     []*TreeOption
+	expanded    map[*TreeOption]bool
+	rows        []treeRow
+	cursor      int
+	offset      int
+	err         error
+	selected    string
+	saved       string
+}
+
+func NewTreeField(label string, selected string, placeholder string) *TreeField {
+	return &TreeField{
+		label:       label,
+		placeholder: placeholder,
+		selected:    selected,
+		expanded:    map[*TreeOption]bool{},
+	}
+}
+
+func (t *TreeField) Label() string {
+	return t.label
+}
+
+// Value returns the value of the chosen option.
+func (t *TreeField) Value() string {
+	return t.selected
+}
+
+// SetOptions sets the roots of the tree.
+func (t *TreeField) SetOptions(options []*TreeOption) {
+	t.options = options
+	t.err = nil
+	t.expanded = map[*TreeOption]bool{}
+	t.expandSelected()
+}
+
+// SetError reports that the options could not be loaded.
+func (t *TreeField) SetError(err error) {
+	t.err = err
+}
+
+// expandSelected expands the ancestors of the chosen option and moves the cursor to it
+func (t *TreeField) expandSelected() {
+	var expand func(options []*TreeOption) bool
+	expand = func(options []*TreeOption) bool {
+		for _, option := range options {
+			if option.Value == t.selected || expand(option.Children) {
+				if option.Value != t.selected {
+					t.expanded[option] = true
+				}
+				return true
+			}
+		}
+		return false
+	}
+	expand(t.options)
+
+	t.refresh()
+	t.cursor = 0
+	for i, row := range t.rows {
+		if row.option.Value == t.selected {
+			t.cursor = i
+			break
+		}
+	}
+}
+
+// refresh lists the options whose ancestors are all expanded
+func (t *TreeField) refresh() {
+	t.rows = t.rows[:0]
+	var walk func(options []*TreeOption, depth, parent int)
+	walk = func(options []*TreeOption, depth, parent int) {
+		for _, option := range options {
+			t.rows = append(t.rows, treeRow{option: option, depth: depth, parent: parent})
+			if t.expanded[option] {
+				walk(option.Children, depth+1, len(t.rows)-1)
+			}
+		}
+	}
+	walk(t.options, 0, -1)
+	t.cursor = min(t.cursor, max(len(t.rows)-1, 0))
+}
+
+func (t *TreeField) Update(form *Form, msg tea.Msg) tea.Cmd {
+	if !t.editing || len(t.rows) == 0 {
+		return nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		row := t.rows[t.cursor]
+		switch msg.String() {
+		case "j", "down":
+			if t.cursor < len(t.rows)-1 {
+				t.cursor++
+			}
+		case "k", "up":
+			if t.cursor > 0 {
+				t.cursor--
+			}
+		case "l", "right":
+			if len(row.option.Children) > 0 {
+				t.expanded[row.option] = true
+				t.refresh()
+			}
+		case "h", "left":
+			if t.expanded[row.option] {
+				delete(t.expanded, row.option)
+				t.refresh()
+			} else if row.parent >= 0 {
+				t.cursor = row.parent
+			}
+		case " ":
+			if len(row.option.Children) > 0 {
+				t.expanded[row.option] = !t.expanded[row.option]
+				t.refresh()
+			}
+		}
+	}
+
+	// Keep the cursor in the listed window
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	} else if t.cursor >= t.offset+maxTreeRows {
+		t.offset = t.cursor - maxTreeRows + 1
+	}
+	return nil
+}
+
+func (t *TreeField) View(form *Form) string {
+	label := form.Pad(t.label + ":")
+	value := t.selected
+	if value == "" {
+		value = t.placeholder
+	}
+
+	if !t.editing {
+		if t.focused {
+			return treeLabelStyle.Render(label + value)
+		}
+		return label + value
+	}
+
+	indent := form.Pad("")
+	var s strings.Builder
+	s.WriteString(treeLabelStyle.Render(label + value))
+	switch {
+	case t.err != nil:
+		s.WriteString("\n" + indent + treeDetailStyle.Render("Failed to load: "+t.err.Error()))
+	case t.options == nil:
+		s.WriteString("\n" + indent + treeDetailStyle.Render("Loading..."))
+	}
+
+	end := min(t.offset+maxTreeRows, len(t.rows))
+	for i := t.offset; i < end; i++ {
+		row := t.rows[i]
+		marker := "  "
+		if len(row.option.Children) > 0 {
+			marker = "▸ "
+			if t.expanded[row.option] {
+				marker = "▾ "
+			}
+		}
+		line := strings.Repeat("  ", row.depth) + marker + row.option.Label
+		if i == t.cursor {
+			line = treeCursorStyle.Render(line)
+		}
+		if row.option.Detail != "" {
+			line += " " + treeDetailStyle.Render(row.option.Detail)
+		}
+		s.WriteString("\n" + indent + line)
+	}
+	if end < len(t.rows) {
+		s.WriteString("\n" + indent + treeDetailStyle.Render("..."))
+	}
+	return s.String()
+}
+
+func (t *TreeField) Focus() tea.Cmd {
+	t.focused = true
+	return nil
+}
+
+func (t *TreeField) Blur() {
+	t.focused = false
+}
+
+func (t *TreeField) Edit() tea.Cmd {
+	t.editing = true
+	t.saved = t.selected
+	t.expandSelected()
+	t.offset = max(t.cursor-maxTreeRows+1, 0)
+	return nil
+}
+
+// Save chooses the option under the cursor.
+func (t *TreeField) Save() {
+	t.editing = false
+	if t.cursor < len(t.rows) {
+		t.selected = t.rows[t.cursor].option.Value
+	}
+}
+
+func (t *TreeField) Cancel() {
+	t.editing = false
+	t.selected = t.saved
+}
+
+func (t *TreeField) Terminator() string {
+	return "enter"
+}
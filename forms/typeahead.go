@@ -0,0 +1,243 @@
+package forms
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	typeaheadLabelStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("15"))
+
+	typeaheadCursorStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("86"))
+
+	typeaheadHintStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("241"))
+)
+
+const (
+	// searchDelay is how long typing must pause before the search runs
+	searchDelay = 250 * time.Millisecond
+	// maxSuggestions limits how many suggestions are listed
+	maxSuggestions = 8
+)
+
+// Suggestion is an option offered by a TypeaheadField.
+type Suggestion struct {
+	// Label is shown in the list of suggestions and once the suggestion is chosen.
+	Label string
+	// Value is returned by the field once the suggestion is chosen.
+	Value string
+}
+
+// SearchFunc returns the suggestions for a query. It runs outside of the
+// update loop, so it may block on network requests.
+type SearchFunc func(query string) ([]Suggestion, error)
+
+// searchTickMsg is sent when typing in a field has paused
+type searchTickMsg struct {
+	field *TypeaheadField
+	query string
+}
+
+// suggestionsMsg carries the results of a search back to the field that started it
+type suggestionsMsg struct {
+	field       *TypeaheadField
+	query       string
+	suggestions []Suggestion
+	err         error
+}
+
+// TypeaheadField implements FormField for choosing one of the suggestions
+// found for the text typed into it.
+type TypeaheadField struct {
+	label       string
+	placeholder string
+	focused     bool
+	editing     bool
+	input       textinput.Model
+	search      SearchFunc
+	suggestions []Suggestion
+	cursor      int
+	searching   bool
+	err         error
+	selected    Suggestion
+	saved       Suggestion
+}
+
+func NewTypeaheadField(label string, selected Suggestion, placeholder string, search SearchFunc) *TypeaheadField {
+	ti := textinput.New()
+	ti.Placeholder = "Type to search"
+	ti.Width = 40
+	ti.Prompt = ""
+
+	return &TypeaheadField{
+		label:       label,
+		placeholder: placeholder,
+		input:       ti,
+		search:      search,
+		selected:    selected,
+	}
+}
+
+func (t *TypeaheadField) Label() string {
+	return t.label
+}
+
+// Value returns the value of the chosen suggestion.
+func (t *TypeaheadField) Value() string {
+	return t.selected.Value
+}
+
+// query returns the text typed into the field
+func (t *TypeaheadField) query() string {
+	return strings.TrimSpace(t.input.Value())
+}
+
+func (t *TypeaheadField) Update(form *Form, msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case searchTickMsg:
+		if msg.field != t || !t.editing || msg.query != t.query() {
+			return nil
+		}
+		return t.runSearch(msg.query)
+	case suggestionsMsg:
+		// Results of searches for text that has since changed are dropped
+		if msg.field != t || !t.editing || msg.query != t.query() {
+			return nil
+		}
+		t.searching = false
+		t.err = msg.err
+		t.suggestions = msg.suggestions[:min(len(msg.suggestions), maxSuggestions)]
+		t.cursor = 0
+		return nil
+	case tea.KeyMsg:
+		if !t.editing {
+			return nil
+		}
+		switch msg.String() {
+		case "up", "ctrl+p":
+			if t.cursor > 0 {
+				t.cursor--
+			}
+			return nil
+		case "down", "ctrl+n", "tab":
+			if t.cursor < len(t.suggestions)-1 {
+				t.cursor++
+			}
+			return nil
+		}
+
+		before := t.query()
+		var cmd tea.Cmd
+		t.input, cmd = t.input.Update(msg)
+		query := t.query()
+		if query == before {
+			return cmd
+		}
+		t.searching = true
+		return tea.Batch(cmd, tea.Tick(searchDelay, func(time.Time) tea.Msg {
+			return searchTickMsg{field: t, query: query}
+		}))
+	}
+
+	if t.editing {
+		var cmd tea.Cmd
+		t.input, cmd = t.input.Update(msg)
+		return cmd
+	}
+	return nil
+}
+
+// runSearch looks up the suggestions for query
+func (t *TypeaheadField) runSearch(query string) tea.Cmd {
+	t.searching = true
+	search := t.search
+	return func() tea.Msg {
+		suggestions, err := search(query)
+		return suggestionsMsg{field: t, query: query, suggestions: suggestions, err: err}
+	}
+}
+
+func (t *TypeaheadField) View(form *Form) string {
+	label := form.Pad(t.label + ":")
+
+	if !t.editing {
+		value := t.selected.Label
+		if value == "" {
+			value = t.placeholder
+		}
+		if t.focused {
+			return typeaheadLabelStyle.Render(label + value)
+		}
+		return label + value
+	}
+
+	indent := form.Pad("")
+	var s strings.Builder
+	s.WriteString(typeaheadLabelStyle.Render(label) + t.input.View())
+	for i, suggestion := range t.suggestions {
+		s.WriteString("\n" + indent)
+		if i == t.cursor {
+			s.WriteString(typeaheadCursorStyle.Render("▸ " + suggestion.Label))
+		} else {
+			s.WriteString("  " + suggestion.Label)
+		}
+	}
+
+	switch {
+	case t.err != nil:
+		s.WriteString("\n" + indent + typeaheadHintStyle.Render("Search failed: "+t.err.Error()))
+	case t.searching && len(t.suggestions) == 0:
+		s.WriteString("\n" + indent + typeaheadHintStyle.Render("Searching..."))
+	case !t.searching && len(t.suggestions) == 0:
+		s.WriteString("\n" + indent + typeaheadHintStyle.Render("No matches"))
+	}
+	return s.String()
+}
+
+func (t *TypeaheadField) Focus() tea.Cmd {
+	t.focused = true
+	return nil
+}
+
+func (t *TypeaheadField) Blur() {
+	t.focused = false
+}
+
+func (t *TypeaheadField) Edit() tea.Cmd {
+	t.editing = true
+	t.saved = t.selected
+	t.input.SetValue("")
+	t.suggestions = nil
+	t.err = nil
+	return tea.Batch(t.input.Focus(), t.runSearch(""))
+}
+
+// Save chooses the highlighted suggestion, keeping the previous choice when nothing matched.
+func (t *TypeaheadField) Save() {
+	t.editing = false
+	t.input.Blur()
+	if !t.searching && t.cursor < len(t.suggestions) {
+		t.selected = t.suggestions[t.cursor]
+	}
+	t.suggestions = nil
+}
+
+func (t *TypeaheadField) Cancel() {
+	t.editing = false
+	t.input.Blur()
+	t.selected = t.saved
+	t.suggestions = nil
+}
+
+func (t *TypeaheadField) Terminator() string {
+	return "enter"
+}
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"errors"
+	"fazure/azure"
+	"fazure/config"
 	"fazure/views"
+	"flag"
 	"fmt"
 	"os"
 
@@ -9,9 +13,64 @@ import (
 )
 
 func main() {
-	p := tea.NewProgram(views.NewModel(), tea.WithAltScreen())
+	demo := flag.Bool("demo", false, "run against built-in mock data instead of Azure DevOps")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [--demo | demo]\n\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Without --demo, AZURE_ORG, AZURE_PROJECT and AZURE_PAT must be set.")
+		fmt.Fprintln(flag.CommandLine.Output(), "AZURE_USER selects whose backlog is shown on startup.")
+		fmt.Fprintln(flag.CommandLine.Output(), "AZURE_TEAM selects the team, the project's default team if unset.")
+		if path, err := config.Path(); err == nil {
+			fmt.Fprintf(flag.CommandLine.Output(), "Settings such as the backlog columns are read from %s.\n", path)
+		}
+		fmt.Fprintln(flag.CommandLine.Output())
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	switch flag.Arg(0) {
+	case "":
+	case "demo":
+		*demo = true
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	service, err := newService(*demo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	p := tea.NewProgram(views.NewModel(service, cfg), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// newService returns the mock client in demo mode and a live Azure DevOps client otherwise
+func newService(demo bool) (azure.WorkItemService, error) {
+	if demo {
+		return azure.NewMockAzureClient(), nil
+	}
+
+	org := os.Getenv("AZURE_ORG")
+	project := os.Getenv("AZURE_PROJECT")
+	pat := os.Getenv("AZURE_PAT")
+	if org == "" || project == "" || pat == "" {
+		return nil, errors.New("AZURE_ORG, AZURE_PROJECT and AZURE_PAT must be set (or run with --demo to use mock data)")
+	}
+
+	client := azure.NewClient(org, project, pat)
+	if team := os.Getenv("AZURE_TEAM"); team != "" {
+		client.Team = team
+	}
+	return client, nil
+}
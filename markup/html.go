@@ -0,0 +1,227 @@
+// Package markup converts the HTML of rich text work item fields to formatted
+// terminal text, and to and from the Markdown and plain text they are edited in.
+package markup
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+// Node is an element or a run of text in a parsed HTML document
+type Node struct {
+	// Tag is the lowercase name of the element, empty for text
+	Tag string
+	// Text is the decoded content of a text node
+	Text     string
+	Attrs    map[string]string
+	Children []*Node
+}
+
+// Attr returns the value of an attribute of the element, empty when it is not set
+func (n *Node) Attr(name string) string {
+	return n.Attrs[name]
+}
+
+var (
+	// voidTags are elements which have no content or end tag
+	voidTags = []string{"area", "base", "br", "col", "hr", "img", "input", "link", "meta", "source", "wbr"}
+	// rawTags are elements whose content is not HTML and is left out
+	rawTags = []string{"script", "style", "title"}
+	// paragraphClosers are elements which end an open paragraph when they start
+	paragraphClosers = []string{
+		"blockquote", "div", "dl", "h1", "h2", "h3", "h4", "h5", "h6",
+		"hr", "ol", "p", "pre", "table", "ul",
+	}
+)
+
+// Parse parses an HTML fragment leniently, the way browsers recover from unclosed
+// and stray tags: list items, paragraphs and table cells are closed implicitly and
+// end tags without a matching start tag are ignored
+func Parse(s string) *Node {
+	root := &Node{Tag: "body"}
+	stack := []*Node{root}
+	top := func() *Node { return stack[len(stack)-1] }
+	// closeTo pops the open elements down to and including the innermost one named
+	// tag, unless one of the boundary elements is reached first
+	closeTo := func(tag string, boundaries ...string) {
+		for i := len(stack) - 1; i > 0; i-- {
+			switch {
+			case stack[i].Tag == tag:
+				stack = stack[:i]
+				return
+			case slices.Contains(boundaries, stack[i].Tag):
+				return
+			}
+		}
+	}
+
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			i = len(s)
+		}
+		if i > 0 {
+			top().Children = append(top().Children, &Node{Text: html.UnescapeString(s[:i])})
+			s = s[i:]
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			end := strings.Index(s, "-->")
+			if end < 0 {
+				return root
+			}
+			s = s[end+3:]
+		case strings.HasPrefix(s, "<!"), strings.HasPrefix(s, "<?"):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				return root
+			}
+			s = s[end+1:]
+		case strings.HasPrefix(s, "</"):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				return root
+			}
+			if tag := tagName(s[2:end]); tag != "" {
+				closeTo(tag)
+			}
+			s = s[end+1:]
+		case len(s) > 1 && isLetter(s[1]):
+			end := tagEnd(s)
+			if end < 0 {
+				return root
+			}
+			tag, attrs := parseTag(s[1:end])
+			s = s[end+1:]
+
+			if slices.Contains(rawTags, tag) {
+				if close := strings.Index(strings.ToLower(s), "</"+tag); close >= 0 {
+					s = s[close:]
+				} else {
+					s = ""
+				}
+				continue
+			}
+
+			if slices.Contains(paragraphClosers, tag) {
+				closeTo("p", "li", "td", "th", "blockquote", "div")
+			}
+			switch tag {
+			case "li":
+				closeTo("li", "ul", "ol")
+			case "dt", "dd":
+				closeTo("dt", "dl")
+				closeTo("dd", "dl")
+			case "tr":
+				closeTo("tr", "table")
+			case "td", "th":
+				closeTo("td", "tr", "table")
+				closeTo("th", "tr", "table")
+			}
+
+			node := &Node{Tag: tag, Attrs: attrs}
+			top().Children = append(top().Children, node)
+			if !slices.Contains(voidTags, tag) {
+				stack = append(stack, node)
+			}
+		default:
+			// A stray < is text
+			top().Children = append(top().Children, &Node{Text: "<"})
+			s = s[1:]
+		}
+	}
+	return root
+}
+
+// tagEnd returns the index of the > ending the tag at the start of s, skipping over
+// quoted attribute values
+func tagEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '>':
+			return i
+		}
+	}
+	return -1
+}
+
+// parseTag parses the name and attributes between the brackets of a start tag
+func parseTag(s string) (string, map[string]string) {
+	s = strings.TrimSuffix(s, "/")
+	name := tagName(s)
+	s = s[len(name):]
+
+	attrs := map[string]string{}
+	for {
+		s = strings.TrimLeft(s, " \t\r\n/")
+		if s == "" {
+			return name, attrs
+		}
+		end := strings.IndexAny(s, " \t\r\n=/")
+		if end < 0 {
+			end = len(s)
+		}
+		key := strings.ToLower(s[:end])
+		s = strings.TrimLeft(s[end:], " \t\r\n")
+		if !strings.HasPrefix(s, "=") {
+			attrs[key] = ""
+			continue
+		}
+		s = strings.TrimLeft(s[1:], " \t\r\n")
+
+		var value string
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			close := strings.IndexByte(s[1:], s[0])
+			if close < 0 {
+				close = len(s) - 1
+			}
+			value, s = s[1:close+1], s[min(close+2, len(s)):]
+		} else {
+			end := strings.IndexAny(s, " \t\r\n")
+			if end < 0 {
+				end = len(s)
+			}
+			value, s = s[:end], s[end:]
+		}
+		attrs[key] = html.UnescapeString(value)
+	}
+}
+
+// tagName returns the lowercase name at the start of a tag
+func tagName(s string) string {
+	end := 0
+	for end < len(s) && (isLetter(s[end]) || s[end] >= '0' && s[end] <= '9' || s[end] == '-' || s[end] == ':') {
+		end++
+	}
+	return strings.ToLower(s[:end])
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// text returns the text content of a node and its descendants
+func (n *Node) text() string {
+	if n.Tag == "" {
+		return n.Text
+	}
+	var s strings.Builder
+	for _, child := range n.Children {
+		if child.Tag == "br" {
+			s.WriteString("\n")
+			continue
+		}
+		s.WriteString(child.text())
+	}
+	return s.String()
+}
@@ -0,0 +1,37 @@
+package markup
+
+import "testing"
+
+// TestParse checks that unclosed and stray tags are recovered from like a browser
+func TestParse(t *testing.T) {
+	root := Parse(`<ul><li>one<li>two &amp; <b>three</ul></b><p>para<div class="x" data-id='7'>text<br>more</div><!-- note --><script>ignored()</script>`)
+
+	if len(root.Children) != 3 {
+		t.Fatalf("got %d top level nodes, want 3: %+v", len(root.Children), root.Children)
+	}
+	list := root.Children[0]
+	if list.Tag != "ul" || len(list.Children) != 2 {
+		t.Fatalf("list = %+v, want a ul with 2 items", list)
+	}
+	if got := list.Children[1].text(); got != "two & three" {
+		t.Errorf("second item text = %q, want %q", got, "two & three")
+	}
+	if p := root.Children[1]; p.Tag != "p" || p.text() != "para" {
+		t.Errorf("paragraph = %+v, want it closed by the div", p)
+	}
+	div := root.Children[2]
+	if div.Attr("class") != "x" || div.Attr("data-id") != "7" {
+		t.Errorf("div attributes = %v", div.Attrs)
+	}
+	if got := div.text(); got != "text\nmore" {
+		t.Errorf("div text = %q, want %q", got, "text\nmore")
+	}
+}
+
+// TestParseText checks that text without tags is kept as it is
+func TestParseText(t *testing.T) {
+	root := Parse("a < b and c > d")
+	if got := root.text(); got != "a < b and c > d" {
+		t.Errorf("text = %q", got)
+	}
+}
@@ -0,0 +1,687 @@
+package markup
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ToMarkdown converts HTML to Markdown. Lines of a paragraph, like the divs the
+// Azure DevOps editor writes for each line, are kept as separate lines, which
+// FromMarkdown turns back into line breaks.
+func ToMarkdown(html string) string {
+	w := &markdownWriter{}
+	return strings.Join(w.blocks(Parse(html), false), "\n")
+}
+
+// markdownWriter converts parsed HTML to lines of Markdown
+type markdownWriter struct{}
+
+// blocks converts the children of a node, separating paragraphs, headings, lists,
+// tables and code blocks with blank lines unless tight
+func (w *markdownWriter) blocks(n *Node, tight bool) []string {
+	var lines []string
+	spaced := false
+	add := func(block []string, blockSpaced bool) {
+		if len(block) == 0 {
+			return
+		}
+		if len(lines) > 0 && (spaced || blockSpaced) && !tight {
+			lines = append(lines, "")
+		}
+		lines = append(lines, block...)
+		spaced = blockSpaced
+	}
+
+	var run strings.Builder
+	flush := func() {
+		add(inlineLines(run.String()), false)
+		run.Reset()
+	}
+	for _, child := range n.Children {
+		if !slices.Contains(blockTags, child.Tag) {
+			w.inline(child, &run)
+			continue
+		}
+		flush()
+		add(w.block(child))
+	}
+	flush()
+	return lines
+}
+
+// inlineLines splits converted inline content at its line breaks, collapsing the
+// whitespace of each line
+func inlineLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		lines = append(lines, escapeLineStart(line))
+	}
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	// Like a browser, a break at the end of a paragraph adds no empty line
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// block converts a block element, telling whether it is set apart from its neighbours
+func (w *markdownWriter) block(n *Node) ([]string, bool) {
+	switch n.Tag {
+	case "p":
+		return w.blocks(n, false), true
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		var run strings.Builder
+		for _, child := range n.Children {
+			w.inline(child, &run)
+		}
+		level, _ := strconv.Atoi(n.Tag[1:])
+		text := strings.Join(strings.Fields(strings.ReplaceAll(run.String(), "\n", " ")), " ")
+		return []string{strings.Repeat("#", level) + " " + text}, true
+	case "ul", "ol":
+		return w.list(n), true
+	case "li":
+		return w.items([]*Node{n}, false, 1), true
+	case "pre":
+		text := strings.TrimPrefix(strings.ReplaceAll(n.text(), "\r\n", "\n"), "\n")
+		lines := []string{"```"}
+		lines = append(lines, strings.Split(strings.TrimRight(text, "\n"), "\n")...)
+		return append(lines, "```"), true
+	case "blockquote":
+		lines := w.blocks(n, false)
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		return lines, true
+	case "table":
+		return w.table(n), true
+	case "hr":
+		return []string{"---"}, true
+	default:
+		return w.blocks(n, false), false
+	}
+}
+
+// inline converts an inline element and its content
+func (w *markdownWriter) inline(n *Node, s *strings.Builder) {
+	if n.Tag == "" {
+		s.WriteString(escapeMarkdown(strings.ReplaceAll(n.Text, "\n", " ")))
+		return
+	}
+
+	content := func() string {
+		var inner strings.Builder
+		for _, child := range n.Children {
+			w.inline(child, &inner)
+		}
+		return inner.String()
+	}
+	// wrap puts a delimiter around content, keeping surrounding spaces outside
+	wrap := func(delim string) {
+		text := content()
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" {
+			s.WriteString(text)
+			return
+		}
+		if text[0] == ' ' {
+			s.WriteString(" ")
+		}
+		s.WriteString(delim + trimmed + delim)
+		if text[len(text)-1] == ' ' {
+			s.WriteString(" ")
+		}
+	}
+
+	switch n.Tag {
+	case "br":
+		s.WriteString("\n")
+	case "img":
+		fmt.Fprintf(s, "![%s](%s)", escapeMarkdown(n.Attr("alt")), n.Attr("src"))
+	case "b", "strong":
+		wrap("**")
+	case "i", "em", "cite", "var":
+		wrap("*")
+	case "s", "strike", "del":
+		wrap("~~")
+	case "code", "kbd", "samp", "tt":
+		code := strings.Join(strings.Fields(n.text()), " ")
+		delim := "`"
+		if strings.Contains(code, "`") {
+			delim = "``"
+			code = " " + code + " "
+		}
+		s.WriteString(delim + code + delim)
+	case "a":
+		href := n.Attr("href")
+		text := strings.TrimSpace(content())
+		switch {
+		case href == "":
+			s.WriteString(text)
+		case href == strings.TrimSpace(n.text()) && isURL(href):
+			s.WriteString(href)
+		default:
+			fmt.Fprintf(s, "[%s](%s)", text, href)
+		}
+	default:
+		if slices.Contains(blockTags, n.Tag) {
+			s.WriteString("\n" + content() + "\n")
+			return
+		}
+		s.WriteString(content())
+	}
+}
+
+// list converts the items of a list
+func (w *markdownWriter) list(n *Node) []string {
+	start := 1
+	if s, err := strconv.Atoi(n.Attr("start")); err == nil {
+		start = s
+	}
+	return w.items(n.Children, n.Tag == "ol", start)
+}
+
+// items converts list items, indenting their nested content under the marker
+func (w *markdownWriter) items(items []*Node, ordered bool, number int) []string {
+	var lines []string
+	for _, item := range items {
+		var body []string
+		switch item.Tag {
+		case "li":
+			body = w.blocks(item, true)
+		case "ul", "ol":
+			// Lists nested directly in lists belong to the previous item
+			for _, line := range w.list(item) {
+				lines = append(lines, "  "+line)
+			}
+			continue
+		default:
+			if strings.TrimSpace(item.text()) == "" {
+				continue
+			}
+			body = w.blocks(&Node{Tag: "li", Children: []*Node{item}}, true)
+		}
+
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+		if len(body) == 0 {
+			body = []string{""}
+		}
+		pad := strings.Repeat(" ", len(marker))
+		for i, line := range body {
+			if i == 0 {
+				lines = append(lines, strings.TrimRight(marker+line, " "))
+			} else if line != "" {
+				lines = append(lines, pad+line)
+			}
+		}
+	}
+	return lines
+}
+
+// table converts a table to a pipe table, whose first row is always the header
+func (w *markdownWriter) table(n *Node) []string {
+	rows, _ := tableRows(n)
+	var lines []string
+	for i, row := range rows {
+		var cells []string
+		for _, cell := range row.Children {
+			if cell.Tag != "td" && cell.Tag != "th" {
+				continue
+			}
+			var s strings.Builder
+			for _, child := range cell.Children {
+				w.inline(child, &s)
+			}
+			text := strings.Join(strings.Fields(s.String()), " ")
+			cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if i == 0 {
+			rules := make([]string, len(cells))
+			for c := range rules {
+				rules[c] = "---"
+			}
+			lines = append(lines, "| "+strings.Join(rules, " | ")+" |")
+		}
+	}
+	return lines
+}
+
+// escapeMarkdown escapes the characters of text which Markdown would read as
+// formatting, leaving underscores inside words alone
+func escapeMarkdown(text string) string {
+	var s strings.Builder
+	runes := []rune(text)
+	for i, r := range runes {
+		switch r {
+		case '\\', '*', '`', '[', ']':
+			s.WriteRune('\\')
+		case '_':
+			if i == 0 || i == len(runes)-1 || !isWordRune(runes[i-1]) || !isWordRune(runes[i+1]) {
+				s.WriteRune('\\')
+			}
+		case '~':
+			if i+1 < len(runes) && runes[i+1] == '~' {
+				s.WriteRune('\\')
+			}
+		}
+		s.WriteRune(r)
+	}
+	return s.String()
+}
+
+// blockStart matches text at the start of a line which Markdown would read as a
+// heading, quote, list item or rule
+var blockStart = regexp.MustCompile(`^(#{1,6}(\s|$)|>|[-+](\s|$)|\d+[.)](\s|$)|(-\s*){3,}$|\|)`)
+
+// escapeLineStart escapes a line which would otherwise start a block
+func escapeLineStart(line string) string {
+	if blockStart.MatchString(line) {
+		if i := strings.IndexFunc(line, func(r rune) bool { return !unicode.IsDigit(r) }); i > 0 {
+			return line[:i] + `\` + line[i:]
+		}
+		return `\` + line
+	}
+	return line
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isURL tells whether text is an absolute web address
+func isURL(text string) bool {
+	return strings.HasPrefix(text, "http://") || strings.HasPrefix(text, "https://")
+}
+
+var (
+	headingLine = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	ruleLine    = regexp.MustCompile(`^\s{0,3}((-\s*){3,}|(\*\s*){3,}|(_\s*){3,})$`)
+	listLine    = regexp.MustCompile(`^(\s*)([-*+]|(\d+)[.)])\s+(.*)$`)
+	fenceLine   = regexp.MustCompile("^\\s*(```+|~~~+)")
+	tableRule   = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+)
+
+// FromMarkdown converts Markdown to HTML. Line breaks inside paragraphs are kept,
+// as descriptions are usually written line by line rather than reflowed.
+func FromMarkdown(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	return strings.Join(markdownBlocks(lines), "")
+}
+
+// markdownBlocks converts lines of Markdown to HTML blocks
+func markdownBlocks(lines []string) []string {
+	var blocks []string
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, "<p>"+markdownInlineLines(paragraph)+"</p>")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case fenceLine.MatchString(line):
+			flush()
+			fence := fenceLine.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, "<pre><code>"+html.EscapeString(strings.Join(code, "\n"))+"</code></pre>")
+		case headingLine.MatchString(trimmed):
+			flush()
+			m := headingLine.FindStringSubmatch(trimmed)
+			level := len(m[1])
+			blocks = append(blocks, fmt.Sprintf("<h%d>%s</h%d>", level, markdownInline(m[2]), level))
+		case ruleLine.MatchString(line):
+			flush()
+			blocks = append(blocks, "<hr>")
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				text := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quote = append(quote, strings.TrimPrefix(text, " "))
+			}
+			i--
+			blocks = append(blocks, "<blockquote>"+strings.Join(markdownBlocks(quote), "")+"</blockquote>")
+		case listLine.MatchString(line):
+			flush()
+			end := i + 1
+			for end < len(lines) && strings.TrimSpace(lines[end]) != "" &&
+				(listLine.MatchString(lines[end]) || lines[end][0] == ' ' || lines[end][0] == '\t') {
+				end++
+			}
+			blocks = append(blocks, markdownList(lines[i:end]))
+			i = end - 1
+		case strings.Contains(line, "|") && i+1 < len(lines) && strings.Contains(lines[i+1], "-") && tableRule.MatchString(lines[i+1]):
+			flush()
+			end := i + 2
+			for end < len(lines) && strings.Contains(lines[end], "|") && strings.TrimSpace(lines[end]) != "" {
+				end++
+			}
+			blocks = append(blocks, markdownTable(lines[i], lines[i+2:end]))
+			i = end - 1
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// markdownInlineLines converts the lines of a paragraph, separated by line breaks
+func markdownInlineLines(lines []string) string {
+	converted := make([]string, len(lines))
+	for i, line := range lines {
+		converted[i] = markdownInline(line)
+	}
+	return strings.Join(converted, "<br>")
+}
+
+// listItem is an item of a Markdown list with the lines that continue it
+type listItem struct {
+	indent  int
+	ordered bool
+	number  int
+	lines   []string
+}
+
+// markdownList converts the lines of a list, nesting items by their indentation
+func markdownList(lines []string) string {
+	var items []listItem
+	for _, line := range lines {
+		if m := listLine.FindStringSubmatch(line); m != nil {
+			number, _ := strconv.Atoi(m[3])
+			items = append(items, listItem{
+				indent:  len(strings.ReplaceAll(m[1], "\t", "    ")),
+				ordered: m[3] != "",
+				number:  number,
+				lines:   []string{m[4]},
+			})
+			continue
+		}
+		last := &items[len(items)-1]
+		last.lines = append(last.lines, strings.TrimSpace(line))
+	}
+
+	var s strings.Builder
+	writeList(&s, items)
+	return s.String()
+}
+
+// writeList writes the items at the indentation of the first one as a list, with
+// the more indented items that follow an item nested in it
+func writeList(s *strings.Builder, items []listItem) {
+	tag := "ul"
+	if items[0].ordered {
+		tag = "ol"
+	}
+	if items[0].ordered && items[0].number != 1 {
+		fmt.Fprintf(s, `<%s start="%d">`, tag, items[0].number)
+	} else {
+		s.WriteString("<" + tag + ">")
+	}
+
+	for i := 0; i < len(items); {
+		item := items[i]
+		s.WriteString("<li>" + markdownInlineLines(item.lines))
+		end := i + 1
+		for end < len(items) && items[end].indent > item.indent {
+			end++
+		}
+		if end > i+1 {
+			writeList(s, items[i+1:end])
+		}
+		s.WriteString("</li>")
+		i = end
+	}
+	s.WriteString("</" + tag + ">")
+}
+
+// markdownTable converts a pipe table
+func markdownTable(header string, rows []string) string {
+	var s strings.Builder
+	s.WriteString("<table><thead><tr>")
+	for _, cell := range tableCells(header) {
+		s.WriteString("<th>" + markdownInline(cell) + "</th>")
+	}
+	s.WriteString("</tr></thead><tbody>")
+	for _, row := range rows {
+		s.WriteString("<tr>")
+		for _, cell := range tableCells(row) {
+			s.WriteString("<td>" + markdownInline(cell) + "</td>")
+		}
+		s.WriteString("</tr>")
+	}
+	s.WriteString("</tbody></table>")
+	return s.String()
+}
+
+// tableCells splits a row of a pipe table at the pipes which are not escaped
+func tableCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = row[:len(row)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// markdownInline converts the emphasis, code spans, links and images of a line
+func markdownInline(text string) string {
+	var s strings.Builder
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && isEscapable(rest[1]):
+			s.WriteString(html.EscapeString(rest[1:2]))
+			i += 2
+			continue
+		case rest[0] == '`':
+			delim := rest[:len(rest)-len(strings.TrimLeft(rest, "`"))]
+			if end := strings.Index(rest[len(delim):], delim); end >= 0 {
+				code := strings.TrimSpace(rest[len(delim) : len(delim)+end])
+				s.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				i += 2*len(delim) + end
+				continue
+			}
+		case strings.HasPrefix(rest, "!["):
+			if alt, url, n, ok := markdownLink(rest[1:]); ok {
+				fmt.Fprintf(&s, `<img src="%s" alt="%s">`, html.EscapeString(url), html.EscapeString(unescapeMarkdown(alt)))
+				i += 1 + n
+				continue
+			}
+		case rest[0] == '[':
+			if label, url, n, ok := markdownLink(rest); ok {
+				fmt.Fprintf(&s, `<a href="%s">%s</a>`, html.EscapeString(url), markdownInline(label))
+				i += n
+				continue
+			}
+		case isURL(rest) && (i == 0 || !isWordRune(rune(text[i-1]))):
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			// Punctuation ending a sentence is not part of the address
+			url := strings.TrimRight(rest[:end], ".,;:!?)")
+			fmt.Fprintf(&s, `<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(url))
+			i += len(url)
+			continue
+		}
+
+		if converted, end, ok := markdownEmphasis(text, i); ok {
+			s.WriteString(converted)
+			i = end
+			continue
+		}
+		// Delimiters without a match are plain text
+		if delim := strings.TrimLeft(rest, "*_~"); len(delim) < len(rest) {
+			s.WriteString(html.EscapeString(rest[:len(rest)-len(delim)]))
+			i += len(rest) - len(delim)
+			continue
+		}
+		s.WriteString(html.EscapeString(rest[:1]))
+		i++
+	}
+	return s.String()
+}
+
+// emphasisDelimiters are the Markdown delimiters of emphasis with the elements
+// they convert to, longest first
+var emphasisDelimiters = []struct{ delim, tag string }{
+	{"**", "strong"}, {"__", "strong"}, {"~~", "del"}, {"*", "em"}, {"_", "em"},
+}
+
+// markdownEmphasis converts emphasis opened at start, returning the index after it
+func markdownEmphasis(text string, start int) (string, int, bool) {
+	for _, emphasis := range emphasisDelimiters {
+		if !strings.HasPrefix(text[start:], emphasis.delim) {
+			continue
+		}
+		end, ok := closingDelimiter(text, start, emphasis.delim)
+		if !ok {
+			return "", 0, false
+		}
+		inner := markdownInline(text[start+len(emphasis.delim) : end])
+		return fmt.Sprintf("<%s>%s</%s>", emphasis.tag, inner, emphasis.tag), end + len(emphasis.delim), true
+	}
+	return "", 0, false
+}
+
+// closingDelimiter finds the delimiter closing emphasis opened at start. The text
+// must not start or end with a space, and underscores only count at word boundaries.
+func closingDelimiter(text string, start int, delim string) (int, bool) {
+	open := start + len(delim)
+	if open >= len(text) || text[open] == ' ' {
+		return 0, false
+	}
+	if delim[0] == '_' && start > 0 && isWordRune(rune(text[start-1])) {
+		return 0, false
+	}
+	for i := open + 1; i+len(delim) <= len(text); i++ {
+		if text[i-1] == '\\' {
+			continue
+		}
+		if text[i-1] == '`' {
+			// Delimiters inside code spans do not count
+			if end := strings.IndexByte(text[i:], '`'); end >= 0 {
+				i += end
+			}
+			continue
+		}
+		if !strings.HasPrefix(text[i:], delim) || text[i-1] == ' ' {
+			continue
+		}
+		// A single delimiter must not be half of a double one
+		if len(delim) == 1 && i+1 < len(text) && text[i+1] == delim[0] {
+			i++
+			continue
+		}
+		if delim[0] == '_' && i+len(delim) < len(text) && isWordRune(rune(text[i+len(delim)])) {
+			continue
+		}
+		return i, true
+	}
+	return 0, false
+}
+
+// markdownLink parses [label](url) at the start of text, returning its length
+func markdownLink(text string) (label, url string, n int, ok bool) {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if !strings.HasPrefix(text[i+1:], "(") {
+				return "", "", 0, false
+			}
+			end := strings.IndexByte(text[i+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+			url := strings.TrimSpace(text[i+2 : i+2+end])
+			// An optional title after the address is left out
+			if space := strings.IndexAny(url, " \t"); space >= 0 {
+				url = url[:space]
+			}
+			return text[1:i], strings.Trim(url, "<>"), i + 3 + end, true
+		}
+	}
+	return "", "", 0, false
+}
+
+// unescapeMarkdown removes the backslashes escaping punctuation
+func unescapeMarkdown(text string) string {
+	var s strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) && isEscapable(text[i+1]) {
+			i++
+		}
+		s.WriteByte(text[i])
+	}
+	return s.String()
+}
+
+// isEscapable tells whether a backslash before c escapes it
+func isEscapable(c byte) bool {
+	return c < unicode.MaxASCII && (unicode.IsPunct(rune(c)) || unicode.IsSymbol(rune(c)))
+}
+
+// blankLines separates the paragraphs of plain text
+var blankLines = regexp.MustCompile(`\n\s*\n`)
+
+// FromPlain converts plain text to HTML, keeping its line breaks and separating
+// paragraphs at blank lines
+func FromPlain(text string) string {
+	var s strings.Builder
+	for _, paragraph := range blankLines.Split(strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n")), -1) {
+		if paragraph == "" {
+			continue
+		}
+		lines := strings.Split(paragraph, "\n")
+		for i, line := range lines {
+			lines[i] = html.EscapeString(strings.TrimRight(line, " \t"))
+		}
+		s.WriteString("<p>" + strings.Join(lines, "<br>") + "</p>")
+	}
+	return s.String()
+}
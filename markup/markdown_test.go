@@ -0,0 +1,115 @@
+package markup
+
+import "testing"
+
+// TestToMarkdown checks the conversion of the HTML written by the Azure DevOps editor
+func TestToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "lines and emphasis",
+			html: "<div>Users are <b>redirected</b> to <i>/home</i></div><div><br></div><div>Fix it</div>",
+			want: "Users are **redirected** to */home*\n\nFix it",
+		},
+		{
+			name: "lists",
+			html: "<ul><li>one<ol><li>nested</li></ol></li><li>two</li></ul>",
+			want: "- one\n  1. nested\n- two",
+		},
+		{
+			name: "links, code and headings",
+			html: `<h2>Notes</h2><p>See <a href="https://x.io/a">docs</a>, https://x.io and <code>a_b</code></p>`,
+			want: "## Notes\n\nSee [docs](https://x.io/a), https://x.io and `a_b`",
+		},
+		{
+			name: "formatting characters in text are escaped",
+			html: "<div>2 * 3 = 6 and [x] but snake_case stays</div><div>- not a list</div>",
+			want: `2 \* 3 = 6 and \[x\] but snake_case stays` + "\n" + `\- not a list`,
+		},
+		{
+			name: "tables",
+			html: "<table><tr><th>A</th><th>B</th></tr><tr><td>1</td><td>x|y</td></tr></table>",
+			want: "| A | B |\n| --- | --- |\n| 1 | x\\|y |",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToMarkdown(tt.html); got != tt.want {
+				t.Errorf("ToMarkdown() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFromMarkdown checks the HTML written for Markdown
+func TestFromMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{
+			name: "paragraphs keep their line breaks",
+			md:   "first line\nsecond *line*\n\nnext & last",
+			want: "<p>first line<br>second <em>line</em></p><p>next &amp; last</p>",
+		},
+		{
+			name: "nested lists",
+			md:   "- one\n  1. nested\n- **two**",
+			want: "<ul><li>one<ol><li>nested</li></ol></li><li><strong>two</strong></li></ul>",
+		},
+		{
+			name: "links, images and code",
+			md:   "[docs](https://x.io) ![shot](a.png) `a*b*` https://y.io.",
+			want: `<p><a href="https://x.io">docs</a> <img src="a.png" alt="shot"> <code>a*b*</code> <a href="https://y.io">https://y.io</a>.</p>`,
+		},
+		{
+			name: "unmatched delimiters and intraword underscores are text",
+			md:   "2 * 3 and snake_case_name and \\*escaped\\*",
+			want: "<p>2 * 3 and snake_case_name and *escaped*</p>",
+		},
+		{
+			name: "headings, rules and code blocks",
+			md:   "# Title\n---\n```\n<b>\n```",
+			want: "<h1>Title</h1><hr><pre><code>&lt;b&gt;</code></pre>",
+		},
+		{
+			name: "tables",
+			md:   "| A | B |\n|---|:-:|\n| 1 | x\\|y |",
+			want: "<table><thead><tr><th>A</th><th>B</th></tr></thead><tbody><tr><td>1</td><td>x|y</td></tr></tbody></table>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromMarkdown(tt.md); got != tt.want {
+				t.Errorf("FromMarkdown() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMarkdownRoundTrip checks that converting to Markdown and back keeps the content
+func TestMarkdownRoundTrip(t *testing.T) {
+	html := `<h2>Steps</h2><ol><li>Open <a href="https://x.io">the app</a></li><li>Log in with <code>user_1</code></li></ol><p>Expected: <b>home</b> &lt;page&gt;</p><pre><code>GET /login</code></pre>`
+	md := ToMarkdown(html)
+	if again := ToMarkdown(FromMarkdown(md)); again != md {
+		t.Errorf("round trip changed the Markdown:\n%s\nto\n%s", md, again)
+	}
+	if got, want := ToPlain(FromMarkdown(md)), ToPlain(html); got != want {
+		t.Errorf("round trip changed the text:\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestFromPlain checks that plain text is escaped and keeps its lines
+func TestFromPlain(t *testing.T) {
+	got := FromPlain("a <b>\nc\n\n\nd  ")
+	want := "<p>a &lt;b&gt;<br>c</p><p>d</p>"
+	if got != want {
+		t.Errorf("FromPlain() = %q, want %q", got, want)
+	}
+}
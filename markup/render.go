@@ -0,0 +1,491 @@
+package markup
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	headingStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	codeStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	linkStyle    = lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Color("39"))
+	faintStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+// blockTags are the elements laid out as blocks of lines rather than inline
+var blockTags = []string{
+	"address", "article", "blockquote", "center", "dd", "details", "div", "dl", "dt",
+	"fieldset", "figure", "footer", "form", "h1", "h2", "h3", "h4", "h5", "h6",
+	"header", "hr", "li", "main", "ol", "p", "pre", "section", "summary", "table", "ul",
+}
+
+// bullets are the markers of unordered list items, by nesting depth
+var bullets = []string{"•", "◦", "▪"}
+
+// Render formats HTML as terminal text wrapped to the given width, with headings,
+// emphasis, links and code styled, lists and quotes indented, tables aligned and
+// images replaced by placeholders
+func Render(html string, width int) string {
+	r := &renderer{styled: true}
+	return strings.Join(r.blocks(Parse(html), width, false), "\n")
+}
+
+// ToPlain converts HTML to unstyled text, with a line for each paragraph and line
+// break and the URLs of links after their text
+func ToPlain(html string) string {
+	r := &renderer{}
+	return strings.Join(r.blocks(Parse(html), 0, false), "\n")
+}
+
+// renderer lays out parsed HTML as lines of text. A width of zero disables wrapping.
+type renderer struct {
+	styled bool
+	// depth is the nesting depth of lists
+	depth int
+}
+
+// inlineStyle is the formatting of a run of text
+type inlineStyle struct {
+	bold      bool
+	italic    bool
+	underline bool
+	strike    bool
+	code      bool
+	link      bool
+	heading   bool
+	faint     bool
+}
+
+// span is a run of text with the same formatting, or a line break
+type span struct {
+	text  string
+	style inlineStyle
+	br    bool
+}
+
+// paint applies a formatting to text when rendering for the terminal
+func (r *renderer) paint(text string, st inlineStyle) string {
+	if !r.styled || st == (inlineStyle{}) {
+		return text
+	}
+	style := lipgloss.NewStyle()
+	switch {
+	case st.code:
+		style = codeStyle
+	case st.link:
+		style = linkStyle
+	case st.heading:
+		style = headingStyle
+	case st.faint:
+		style = faintStyle
+	}
+	if st.bold {
+		style = style.Bold(true)
+	}
+	if st.italic {
+		style = style.Italic(true)
+	}
+	if st.underline {
+		style = style.Underline(true)
+	}
+	if st.strike {
+		style = style.Strikethrough(true)
+	}
+	return style.Render(text)
+}
+
+// inner returns the width left after indenting by n columns
+func inner(width, n int) int {
+	if width == 0 {
+		return 0
+	}
+	return max(width-n, 10)
+}
+
+// blocks lays out the children of a node, separating paragraphs, headings, lists,
+// tables and code blocks with blank lines unless tight
+func (r *renderer) blocks(n *Node, width int, tight bool) []string {
+	var lines []string
+	spaced := false
+	add := func(block []string, blockSpaced bool) {
+		if len(block) == 0 {
+			return
+		}
+		if len(lines) > 0 && (spaced || blockSpaced) && !tight {
+			lines = append(lines, "")
+		}
+		lines = append(lines, block...)
+		spaced = blockSpaced
+	}
+
+	var run []span
+	for _, child := range n.Children {
+		if !slices.Contains(blockTags, child.Tag) {
+			r.inline(child, inlineStyle{}, &run)
+			continue
+		}
+		add(r.layout(run, width), false)
+		run = nil
+		block, blockSpaced := r.block(child, width)
+		add(block, blockSpaced)
+	}
+	add(r.layout(run, width), false)
+	return lines
+}
+
+// block lays out a block element, telling whether it is set apart from its neighbours
+func (r *renderer) block(n *Node, width int) ([]string, bool) {
+	switch n.Tag {
+	case "p":
+		return r.blocks(n, width, false), true
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		var run []span
+		r.inline(n, inlineStyle{heading: true, bold: true, underline: n.Tag == "h1"}, &run)
+		return r.layout(run, width), true
+	case "ul", "ol":
+		return r.list(n, width), r.depth == 0
+	case "li":
+		return r.items([]*Node{n}, false, 1, width), false
+	case "pre":
+		return r.pre(n, width), true
+	case "blockquote":
+		return r.indent(r.blocks(n, inner(width, 2), false), r.paint("│ ", inlineStyle{faint: true})), true
+	case "table":
+		return r.table(n, width), true
+	case "hr":
+		return []string{r.paint(strings.Repeat("─", max(width, 10)), inlineStyle{faint: true})}, true
+	case "dd":
+		return r.indent(r.blocks(n, inner(width, 2), false), "  "), false
+	default:
+		return r.blocks(n, width, false), false
+	}
+}
+
+// indent prefixes every line
+func (r *renderer) indent(lines []string, prefix string) []string {
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return lines
+}
+
+// inline collects the runs of text of an inline element with its formatting
+func (r *renderer) inline(n *Node, st inlineStyle, run *[]span) {
+	if n.Tag == "" {
+		*run = append(*run, span{text: n.Text, style: st})
+		return
+	}
+
+	switch n.Tag {
+	case "br":
+		*run = append(*run, span{br: true})
+		return
+	case "img":
+		placeholder := "[image]"
+		if alt := strings.TrimSpace(n.Attr("alt")); alt != "" {
+			placeholder = "[image: " + alt + "]"
+		}
+		*run = append(*run, span{text: placeholder, style: inlineStyle{faint: true}})
+		return
+	case "b", "strong", "th":
+		st.bold = true
+	case "i", "em", "cite", "var":
+		st.italic = true
+	case "u", "ins":
+		st.underline = true
+	case "s", "strike", "del":
+		st.strike = true
+	case "code", "kbd", "samp", "tt":
+		st.code = true
+	case "a":
+		st.link = true
+	}
+
+	// Blocks inside inline content, like paragraphs in table cells, start a new line
+	if slices.Contains(blockTags, n.Tag) && len(*run) > 0 {
+		*run = append(*run, span{br: true})
+	}
+	for _, child := range n.Children {
+		r.inline(child, st, run)
+	}
+
+	if n.Tag == "a" {
+		href := n.Attr("href")
+		text := strings.TrimSpace(n.text())
+		if href != "" && href != text && strings.TrimPrefix(href, "mailto:") != text {
+			*run = append(*run, span{text: " (" + href + ")", style: inlineStyle{faint: true}})
+		}
+	}
+}
+
+// layout wraps runs of text to the width, collapsing whitespace like a browser
+func (r *renderer) layout(run []span, width int) []string {
+	var lines []string
+	var line strings.Builder
+	lineWidth := 0
+	space := false
+	flush := func() {
+		lines = append(lines, line.String())
+		line.Reset()
+		lineWidth = 0
+		space = false
+	}
+
+	for _, s := range run {
+		if s.br {
+			flush()
+			continue
+		}
+		if s.text != "" && unicode.IsSpace(rune(s.text[0])) {
+			space = true
+		}
+		for i, word := range strings.Fields(s.text) {
+			if i > 0 {
+				space = true
+			}
+			w := ansi.StringWidth(word)
+			gap := 0
+			if space && lineWidth > 0 {
+				gap = 1
+			}
+			if width > 0 && lineWidth > 0 && lineWidth+gap+w > width {
+				flush()
+				gap = 0
+			}
+			if gap > 0 {
+				line.WriteString(" ")
+				lineWidth++
+			}
+			// Words longer than a line are broken up
+			for width > 0 && lineWidth+w > width {
+				head := ansi.Truncate(word, width-lineWidth, "")
+				if head == "" {
+					// A wide character which does not fit at all takes the line anyway
+					_, size := utf8.DecodeRuneInString(word)
+					head = word[:size]
+				}
+				line.WriteString(r.paint(head, s.style))
+				flush()
+				word = word[len(head):]
+				w = ansi.StringWidth(word)
+			}
+			line.WriteString(r.paint(word, s.style))
+			lineWidth += w
+			space = false
+		}
+		if s.text != "" && unicode.IsSpace(rune(s.text[len(s.text)-1])) {
+			space = true
+		}
+	}
+	if lineWidth > 0 {
+		flush()
+	}
+	return lines
+}
+
+// list lays out the items of a list
+func (r *renderer) list(n *Node, width int) []string {
+	start := 1
+	if s, err := strconv.Atoi(n.Attr("start")); err == nil {
+		start = s
+	}
+	return r.items(n.Children, n.Tag == "ol", start, width)
+}
+
+// items lays out list items with a bullet or number before the first line of each
+func (r *renderer) items(items []*Node, ordered bool, number int, width int) []string {
+	r.depth++
+	defer func() { r.depth-- }()
+
+	var lines []string
+	for _, item := range items {
+		var marker string
+		switch {
+		case item.Tag == "ul" || item.Tag == "ol":
+			// Lists nested directly in lists are indented without a marker
+			marker = "  "
+		case item.Tag != "li":
+			if strings.TrimSpace(item.text()) == "" {
+				continue
+			}
+			marker = "  "
+		case ordered:
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		default:
+			marker = bullets[(r.depth-1)%len(bullets)] + " "
+		}
+
+		var body []string
+		if item.Tag == "li" {
+			body = r.blocks(item, inner(width, ansi.StringWidth(marker)), true)
+		} else {
+			body = r.blocks(&Node{Tag: "li", Children: []*Node{item}}, inner(width, ansi.StringWidth(marker)), true)
+		}
+		if len(body) == 0 {
+			body = []string{""}
+		}
+		pad := strings.Repeat(" ", ansi.StringWidth(marker))
+		for i, line := range body {
+			if i == 0 {
+				lines = append(lines, r.paint(marker, inlineStyle{faint: true})+line)
+			} else {
+				lines = append(lines, pad+line)
+			}
+		}
+	}
+	return lines
+}
+
+// pre lays out preformatted text as it is, cutting off lines wider than the width
+func (r *renderer) pre(n *Node, width int) []string {
+	text := strings.ReplaceAll(n.text(), "\r\n", "\n")
+	text = strings.TrimPrefix(text, "\n")
+	text = strings.TrimRight(text, "\n")
+	text = strings.ReplaceAll(text, "\t", "    ")
+
+	prefix := "    "
+	if r.styled {
+		prefix = r.paint("│ ", inlineStyle{faint: true})
+	}
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if width > 0 {
+			line = ansi.Truncate(line, inner(width, 2), "…")
+		}
+		lines = append(lines, prefix+r.paint(line, inlineStyle{code: true}))
+	}
+	return lines
+}
+
+// tableRows returns the rows of a table, telling whether the first is a header
+func tableRows(n *Node) ([]*Node, bool) {
+	var rows []*Node
+	header := false
+	for _, child := range n.Children {
+		switch child.Tag {
+		case "tr":
+			rows = append(rows, child)
+		case "thead", "tbody", "tfoot":
+			for _, row := range child.Children {
+				if row.Tag == "tr" {
+					if child.Tag == "thead" && len(rows) == 0 {
+						header = true
+					}
+					rows = append(rows, row)
+				}
+			}
+		}
+	}
+	if len(rows) > 0 && !header {
+		header = true
+		for _, cell := range rows[0].Children {
+			if cell.Tag == "td" {
+				header = false
+			}
+		}
+	}
+	return rows, header
+}
+
+// table lays out a table with aligned columns, narrowing the widest columns and
+// wrapping their cells when the table is wider than the width
+func (r *renderer) table(n *Node, width int) []string {
+	rows, header := tableRows(n)
+	var cells [][][]span
+	columns := 0
+	for i, row := range rows {
+		var cols [][]span
+		for _, cell := range row.Children {
+			if cell.Tag != "td" && cell.Tag != "th" {
+				continue
+			}
+			var run []span
+			for _, child := range cell.Children {
+				r.inline(child, inlineStyle{bold: header && i == 0}, &run)
+			}
+			cols = append(cols, run)
+		}
+		cells = append(cells, cols)
+		columns = max(columns, len(cols))
+	}
+	if columns == 0 {
+		return nil
+	}
+
+	widths := make([]int, columns)
+	for _, row := range cells {
+		for c, cell := range row {
+			for _, line := range r.layout(cell, 0) {
+				widths[c] = max(widths[c], ansi.StringWidth(line))
+			}
+		}
+	}
+	if width > 0 {
+		available := width - 3*(columns-1)
+		for sum(widths) > available {
+			widest := 0
+			for c := range widths {
+				if widths[c] > widths[widest] {
+					widest = c
+				}
+			}
+			if widths[widest] <= 5 {
+				break
+			}
+			widths[widest]--
+		}
+	}
+
+	separator := r.paint(" │ ", inlineStyle{faint: true})
+	var lines []string
+	for i, row := range cells {
+		var wrapped [][]string
+		height := 1
+		for c := range columns {
+			var cell []string
+			if c < len(row) {
+				cell = r.layout(row[c], widths[c])
+			}
+			wrapped = append(wrapped, cell)
+			height = max(height, len(cell))
+		}
+		for l := range height {
+			var parts []string
+			for c, cell := range wrapped {
+				text := ""
+				if l < len(cell) {
+					text = cell[l]
+				}
+				if c < columns-1 {
+					text += strings.Repeat(" ", max(widths[c]-ansi.StringWidth(text), 0))
+				}
+				parts = append(parts, text)
+			}
+			lines = append(lines, strings.TrimRight(strings.Join(parts, separator), " "))
+		}
+		if i == 0 && header {
+			var rules []string
+			for _, w := range widths {
+				rules = append(rules, strings.Repeat("─", w))
+			}
+			lines = append(lines, r.paint(strings.Join(rules, "─┼─"), inlineStyle{faint: true}))
+		}
+	}
+	return lines
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
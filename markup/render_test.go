@@ -0,0 +1,84 @@
+package markup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestRender checks the layout of the elements of rich text fields
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name  string
+		html  string
+		width int
+		want  string
+	}{
+		{
+			name:  "divs are lines and empty divs blank lines",
+			html:  "<div>first line</div><div><br></div><div>second <b>line</b></div>",
+			width: 40,
+			want:  "first line\n\nsecond line",
+		},
+		{
+			name:  "paragraphs are wrapped and separated",
+			html:  "<p>the quick brown fox jumps over the lazy dog</p><p>end</p>",
+			width: 20,
+			want:  "the quick brown fox\njumps over the lazy\ndog\n\nend",
+		},
+		{
+			name:  "nested lists",
+			html:  `<ol start="3"><li>three<ul><li>nested</li></ul></li><li>four</li></ol>`,
+			width: 40,
+			want:  "3. three\n   ◦ nested\n4. four",
+		},
+		{
+			name:  "links and images",
+			html:  `See <a href="https://example.com">the docs</a> and <img src="a.png" alt="diagram">`,
+			width: 80,
+			want:  "See the docs (https://example.com) and [image: diagram]",
+		},
+		{
+			name:  "code blocks keep their layout",
+			html:  "<pre>if x {\n\treturn\n}</pre>",
+			width: 40,
+			want:  "│ if x {\n│     return\n│ }",
+		},
+		{
+			name:  "tables are aligned",
+			html:  "<table><tr><th>Name</th><th>State</th></tr><tr><td>Login</td><td>Done</td></tr></table>",
+			width: 40,
+			want:  "Name  │ State\n──────┼──────\nLogin │ Done",
+		},
+		{
+			name:  "tables wider than the width wrap their widest column",
+			html:  "<table><tr><td>a</td><td>one two three four</td></tr></table>",
+			width: 14,
+			want:  "a │ one two\n  │ three four",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ansi.Strip(Render(tt.html, tt.width))
+			if got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+			for _, line := range strings.Split(got, "\n") {
+				if ansi.StringWidth(line) > tt.width {
+					t.Errorf("line %q is wider than %d", line, tt.width)
+				}
+			}
+		})
+	}
+}
+
+// TestToPlain checks that plain text is not wrapped
+func TestToPlain(t *testing.T) {
+	got := ToPlain("<h1>Title</h1><p>a long paragraph that is not wrapped at any width</p><ul><li>item</li></ul>")
+	want := "Title\n\na long paragraph that is not wrapped at any width\n\n• item"
+	if got != want {
+		t.Errorf("ToPlain() = %q, want %q", got, want)
+	}
+}
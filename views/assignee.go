@@ -0,0 +1,45 @@
+package views
+
+import (
+	"fazure/azure"
+	"fazure/forms"
+)
+
+// identitySuggestion offers an identity in the Assigned To picker, choosing its identity reference
+func identitySuggestion(identity azure.Identity) forms.Suggestion {
+	return forms.Suggestion{Label: identity.Ref(), Value: identity.Ref()}
+}
+
+// assigneeSuggestion returns the current assignee of a work item as chosen in the Assigned To picker
+func assigneeSuggestion(item *azure.WorkItem) forms.Suggestion {
+	if item.Assignee.Ref() != "" {
+		return identitySuggestion(item.Assignee)
+	}
+	return forms.Suggestion{Label: item.AssignedTo, Value: item.AssignedTo}
+}
+
+// newAssigneeField creates the Assigned To picker. It lists the members of the team
+// until a name is typed, and then the matching users of the organization.
+func newAssigneeField(m Model, selected forms.Suggestion) *forms.TypeaheadField {
+	return forms.NewTypeaheadField("Assigned To", selected, unassigned, func(query string) ([]forms.Suggestion, error) {
+		var identities []azure.Identity
+		var err error
+		if query == "" {
+			identities, err = m.azure.GetTeamMembers()
+		} else {
+			identities, err = m.azure.SearchIdentities(query)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		suggestions := make([]forms.Suggestion, 0, len(identities)+1)
+		for _, identity := range identities {
+			suggestions = append(suggestions, identitySuggestion(identity))
+		}
+		if query == "" {
+			suggestions = append(suggestions, forms.Suggestion{Label: unassigned})
+		}
+		return suggestions, nil
+	})
+}
@@ -2,125 +2,388 @@ package views
 
 import (
 	"fazure/azure"
+	"fazure/config"
 	"fmt"
+	"slices"
 	"strconv"
 
-	"github.com/charmbracelet/bubbles/table"
-	"github.com/charmbracelet/lipgloss"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// workItemsLoadedMsg is sent when the backlog query has completed
+type workItemsLoadedMsg struct {
+	items []azure.WorkItem
+	err   error
+}
+
+// stateAdvancedMsg is sent when a work item has been moved to the next state of its workflow
+type stateAdvancedMsg struct {
+	from string
+	item *azure.WorkItem
+	err  error
+}
+
 type BacklogView struct {
-	workItems []azure.WorkItem
-	table     table.Model
+	workItems  []azure.WorkItem
+	visible    []int
+	highlights map[int]map[string][]int
+	columns    []config.Column
+	tree       bool
+	collapsed  map[int]bool
+	nodes      []treeNode
+	table      *dataTable
+	width      int
+	filter     *filterPanel
+	picker     *columnPicker
+	search     *searchBar
+	sortKeys   []columnSort
+	sortPrefix string
+	loading    bool
+	err        error
+	status     string
+	statusErr  error
 }
 
 func (v *BacklogView) Init(m Model) tea.Cmd {
+	v.search = newSearchBar()
+	v.sortKeys = m.sort
+	v.tree = m.tree
+	v.collapsed = map[int]bool{}
+	v.columns = backlogColumns(m.config)
+	v.table = newDataTable(nil, nil, 1)
+	v.resize(m)
+	v.refreshRows()
+	return v.load(m)
+}
+
+// load runs the backlog query of the model, fetching the fields shown in the table
+func (v *BacklogView) load(m Model) tea.Cmd {
+	v.loading = true
+	v.err = nil
+	params := m.query
+	params.Fields = backlogFields(v.columns)
+	params.Relations = v.tree
 	return func() tea.Msg {
-		items, _ := m.azure.QueryWorkItems(azure.QueryParams{
-			AssignedTo: m.user,
-			State:      "Active",
-		})
-		return items
+		items, err := m.azure.QueryWorkItems(params)
+		return workItemsLoadedMsg{items: items, err: err}
 	}
 }
 
 func (v *BacklogView) View(m Model) string {
 	var s string
-	s += TitleStyle.Render(fmt.Sprintf("Backlog: %s", m.user))
+	s += TitleStyle.Render("Backlog")
+	s += "\n"
+	s += CommentDateStyle.Render(filterSummary(m.query))
 	s += "\n\n"
 
-	if len(v.workItems) == 0 {
-		s += "No work items found for this user.\n\n"
-	} else {
+	switch {
+	case v.filter != nil:
+		s += v.filter.View(len(v.workItems))
+		s += HelpStyle.Render("Press 'enter' to edit a filter • 'f' or 'esc' to close filters")
+		return s
+	case v.picker != nil:
+		s += v.picker.View(m)
+		s += HelpStyle.Render("Press 'enter' to edit • 'c' or 'esc' to close the column picker")
+		return s
+	case v.err != nil:
+		s += errorBanner(v.err, getContentWidth(m.terminalWidth), true)
+		s += "\n\n"
+	case v.loading:
+		s += "Loading work items...\n\n"
+	case len(v.workItems) == 0:
+		s += "No work items match the current filters.\n\n"
+	default:
 		s += v.table.View()
 		s += "\n\n"
 	}
 
-	s += HelpStyle.Render("Press 'enter' to view details • 'esc' to search again • 'q' to quit")
+	if v.statusErr != nil {
+		s += ErrorStyle.Render(describeError(v.statusErr)) + "\n"
+	} else if v.status != "" {
+		s += SuccessStyle.Render(v.status) + "\n"
+	}
+
+	if v.sortPrefix != "" {
+		s += HelpStyle.Render(fmt.Sprintf("Press a column number (1-%d) to sort by • any other key to cancel", min(len(v.columns), 9)))
+		return s
+	}
+
+	if v.search.active || v.search.Value() != "" {
+		s += v.search.View(len(v.visible), len(v.workItems))
+		if v.search.active {
+			s += HelpStyle.Render("Press 'enter' to browse matches • 'esc' to clear the search")
+			return s
+		}
+		s += "\n"
+	}
+
+	if v.tree {
+		s += HelpStyle.Render("Press 'space' to expand/collapse • 'h'/'l' to collapse/expand • 't' for the flat list\n")
+	} else {
+		s += HelpStyle.Render("Press 't' for the tree\n")
+	}
+	s += HelpStyle.Render("Press 'enter' to view details • '/' to search • 's'/'S' + column to sort • 'a' to advance state • 'b' for the taskboard • 'B' for the board • 'p' for the sprint • 'n' for a new work item • 'f' to filter • 'c' for columns • 'r' to refresh • 'esc' to search again • 'q' to quit")
 	return s
 }
 
 func (v *BacklogView) Update(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
-
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.resize(m)
+		v.refreshRows()
+		return m, nil
 	case tea.KeyMsg:
+		if v.filter != nil {
+			return v.updateFilter(m, msg)
+		}
+		if v.picker != nil {
+			return v.updatePicker(m, msg)
+		}
+		if v.search.active {
+			return v.updateSearch(m, msg)
+		}
+		if v.sortPrefix != "" {
+			return v.updateSort(m, msg)
+		}
+		if v.tree && v.updateTree(msg) {
+			return m, nil
+		}
+
 		switch msg.String() {
+		case "t":
+			v.tree = !v.tree
+			m.tree = v.tree
+			if v.tree {
+				// The flat list is loaded without relations
+				return m, v.load(m)
+			}
+			v.refreshRows()
+			return m, nil
+		case "s", "S":
+			v.sortPrefix = msg.String()
+			return m, nil
+		case "f":
+			v.filter = newFilterPanel(m.query, v.workItems)
+			return m, loadClassification(m)
+		case "c":
+			v.picker = newColumnPicker(v.columns)
+			return m, nil
+		case "n":
+			m.view = &CreateView{parent: v.GetSelectedWorkItem()}
+			return m, m.view.Init(m)
+		case "a":
+			return m, v.advance(m)
+		case "b":
+			m.view = &TaskboardView{}
+			return m, m.view.Init(m)
+		case "B":
+			m.view = &BoardView{}
+			return m, m.view.Init(m)
+		case "p":
+			m.view = &SprintView{}
+			return m, m.view.Init(m)
+		case "/":
+			return m, v.search.Focus()
 		case "enter":
+			item := v.GetSelectedWorkItem()
+			if item == nil {
+				return m, nil
+			}
 			m.view = &DetailsView{
-				item: v.GetSelectedWorkItem(),
+				item: item,
 			}
 			return m, m.view.Init(m)
+		case "r":
+			return m, v.load(m)
 		case "esc":
+			if v.search.Value() != "" {
+				v.search.Reset()
+				v.refreshRows()
+				return m, nil
+			}
 			m.view = &LoginView{}
 			return m, m.view.Init(m)
 		}
-	case []azure.WorkItem:
-		v.workItems = msg
-		v.table = createTable(v.workItems, m)
+	case classificationLoadedMsg:
+		if v.filter != nil {
+			v.filter.setClassification(msg)
+		}
+		return m, nil
+	case stateAdvancedMsg:
+		v.advanced(msg)
+		return m, nil
+	case workItemsLoadedMsg:
+		v.loading = false
+		v.err = msg.err
+		// The selection indexes the work items being replaced
+		selected := v.selectedID()
+		v.workItems = msg.items
+		v.buildRows(selected)
 	}
 
-	v.table, cmd = v.table.Update(msg)
-	return m, cmd
+	return m, v.table.Update(msg)
+}
+
+// resize fits the table to the terminal; the columns are fitted when the rows are refreshed
+func (v *BacklogView) resize(m Model) {
+	v.table.SetHeight(m.terminalHeight - 12)
+	v.width = m.terminalWidth - 8 // Adjust for padding/margin
 }
 
-// CreateTable creates and configures a table from backlog items
-func createTable(items []azure.WorkItem, m Model) table.Model {
-	w := m.terminalWidth - 8 // Adjust for padding/margin
-	h := m.terminalHeight - 10
+// refreshRows rebuilds the table rows from the loaded work items that match the search,
+// in sort order or as a tree, keeping the selected work item under the cursor
+func (v *BacklogView) refreshRows() {
+	v.buildRows(v.selectedID())
+}
 
-	calcW := func(perc float64) int {
-		return int(float64(w) * perc)
+// buildRows rebuilds the table rows, placing the cursor on the work item with the given ID
+func (v *BacklogView) buildRows(selected int) {
+	v.visible = v.visible[:0]
+	v.highlights = map[int]map[string][]int{}
+	query := v.search.Value()
+	for i, item := range v.workItems {
+		h, ok := matchWorkItem(query, item)
+		if !ok {
+			continue
+		}
+		v.highlights[i] = h
+		v.visible = append(v.visible, i)
+	}
+	sortItems(v.workItems, v.visible, v.sortKeys)
+
+	v.nodes = nil
+	if v.tree {
+		v.visible, v.nodes = buildTree(v.workItems, v.visible, v.collapsed)
 	}
+	treeCol := v.treeColumn()
 
-	columns := []table.Column{
-		{Title: "ID", Width: calcW(0.08)},
-		{Title: "Type", Width: calcW(0.12)},
-		{Title: "Title", Width: calcW(0.45)},
-		{Title: "Assigned To", Width: calcW(0.15)},
-		{Title: "State", Width: calcW(0.12)},
-		{Title: "Priority", Width: calcW(0.08)},
+	rows := make([][]string, len(v.visible))
+	cursor := 0
+	for i, index := range v.visible {
+		item := v.workItems[index]
+		rows[i] = make([]string, len(v.columns))
+		for col, c := range v.columns {
+			rows[i][col] = fieldText(c.Field, item.Field(c.Field))
+		}
+		if v.tree {
+			rows[i][treeCol] = v.nodes[i].prefix() + rows[i][treeCol] + v.nodes[i].suffix()
+		}
+		if item.ID == selected {
+			cursor = i
+		}
+	}
+
+	titles := make([]string, len(v.columns))
+	for col, c := range v.columns {
+		titles[col] = columnTitle(c) + sortIndicator(v.sortKeys, c.Field)
+	}
+	// Each cell is padded by one space on either side
+	widths := fitColumns(titles, rows, v.width-2*len(v.columns))
+	v.table.columns = make([]tableColumn, len(v.columns))
+	for col := range v.columns {
+		v.table.columns[col] = tableColumn{Title: titles[col], Width: widths[col]}
 	}
 
-	rows := []table.Row{}
-	for _, item := range items {
-		rows = append(rows, table.Row{
-			strconv.Itoa(item.ID),
-			string(item.Type),
-			item.Title,
-			item.AssignedTo,
-			item.State,
-			strconv.Itoa(item.Priority),
+	v.table.SetRows(rows)
+	v.table.SetCursor(cursor)
+	v.table.styleCell = func(row, col int, text string) string {
+		index := v.visible[row]
+		field := v.columns[col].Field
+		positions := v.highlights[index][field]
+		if v.tree && col == treeCol {
+			titleLen := len([]rune(v.workItems[index].Field(field)))
+			return styleTreeCell(text, v.nodes[row], titleLen, positions)
+		}
+		return highlightRunes(text, positions, func(s string) string {
+			return SearchMatchStyle.Render(s)
 		})
 	}
+}
 
-	t := table.New(
-		table.WithColumns(columns),
-		table.WithRows(rows),
-		table.WithFocused(true),
-		table.WithHeight(h),
-	)
+// treeColumn returns the column that shows the tree: the title column, or the first column
+func (v *BacklogView) treeColumn() int {
+	return max(slices.IndexFunc(v.columns, func(c config.Column) bool {
+		return c.Field == azure.FieldTitle
+	}), 0)
+}
 
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
-		BorderBottom(true).
-		Bold(true)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
-		Bold(false)
+// updateSort handles the column number following 's' (primary) or 'S' (secondary sort key)
+func (v *BacklogView) updateSort(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	secondary := v.sortPrefix == "S"
+	v.sortPrefix = ""
+
+	col, err := strconv.Atoi(msg.String())
+	if err != nil || col < 1 || col > len(v.columns) {
+		return m, nil
+	}
 
-	t.SetStyles(s)
-	return t
+	v.sortKeys = cycleSort(v.sortKeys, v.columns[col-1].Field, secondary)
+	m.sort = v.sortKeys
+	v.refreshRows()
+	return m, nil
+}
+
+// advance moves the selected work item to the next state of its workflow
+func (v *BacklogView) advance(m Model) tea.Cmd {
+	item := v.GetSelectedWorkItem()
+	if item == nil {
+		return nil
+	}
+
+	id, rev, itemType, state := item.ID, item.Rev, item.Type, item.State
+	v.status = fmt.Sprintf("Advancing #%d...", id)
+	v.statusErr = nil
+	return func() tea.Msg {
+		workflow, err := m.azure.GetWorkflow(itemType)
+		if err != nil {
+			return stateAdvancedMsg{err: err}
+		}
+		next, ok := workflow.Next(state)
+		if !ok {
+			return stateAdvancedMsg{err: fmt.Errorf("%s #%d cannot advance from %s", itemType, id, state)}
+		}
+		updated, err := m.azure.UpdateWorkItem(id, rev, []azure.PatchOperation{azure.SetField(azure.FieldState, next)})
+		return stateAdvancedMsg{from: state, item: updated, err: err}
+	}
+}
+
+// advanced shows the outcome of advancing a work item and updates its row
+func (v *BacklogView) advanced(msg stateAdvancedMsg) {
+	if msg.err != nil {
+		v.status = ""
+		v.statusErr = msg.err
+		return
+	}
+
+	v.status = fmt.Sprintf("#%d %s → %s", msg.item.ID, msg.from, msg.item.State)
+	v.statusErr = nil
+	for i := range v.workItems {
+		if v.workItems[i].ID == msg.item.ID {
+			relations := v.workItems[i].Relations
+			v.workItems[i] = *msg.item
+			if v.workItems[i].Relations == nil {
+				v.workItems[i].Relations = relations
+			}
+		}
+	}
+	v.refreshRows()
+}
+
+// selectedID returns the ID of the selected work item, or 0 if there is none
+func (v *BacklogView) selectedID() int {
+	if item := v.GetSelectedWorkItem(); item != nil {
+		return item.ID
+	}
+	return 0
 }
 
 func (v *BacklogView) GetSelectedWorkItem() *azure.WorkItem {
-	if len(v.workItems) == 0 {
+	if v.table == nil || v.table.Cursor() >= len(v.visible) {
+		return nil
+	}
+	selectedIndex := v.visible[v.table.Cursor()]
+	if selectedIndex >= len(v.workItems) {
 		return nil
 	}
-	selectedIndex := v.table.Cursor()
 	return &v.workItems[selectedIndex]
 }
@@ -0,0 +1,52 @@
+package views
+
+import (
+	"errors"
+	"fazure/azure"
+	"fmt"
+	"testing"
+)
+
+// backlogItems returns work items with the given IDs
+func backlogItems(ids ...int) []azure.WorkItem {
+	items := make([]azure.WorkItem, len(ids))
+	for i, id := range ids {
+		items[i] = azure.WorkItem{ID: id, Type: azure.Task, Title: fmt.Sprintf("Task %d", id)}
+	}
+	return items
+}
+
+// TestBacklogReload checks that reloading keeps the selected work item when it is
+// still loaded, and copes with fewer work items or none at all
+func TestBacklogReload(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      workItemsLoadedMsg
+		selected int
+	}{
+		{"selected item moved", workItemsLoadedMsg{items: backlogItems(9, 3, 1)}, 9},
+		{"selected item gone", workItemsLoadedMsg{items: backlogItems(1, 2)}, 1},
+		{"no items", workItemsLoadedMsg{}, 0},
+		{"error", workItemsLoadedMsg{err: errors.New("offline")}, 0},
+	}
+
+	for _, tt := range tests {
+		v := &BacklogView{
+			search:    newSearchBar(),
+			collapsed: map[int]bool{},
+			columns:   defaultColumns,
+			table:     newDataTable(nil, nil, 5),
+			width:     100,
+		}
+		v.Update(Model{}, workItemsLoadedMsg{items: backlogItems(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)})
+		v.table.SetCursor(8)
+
+		v.Update(Model{}, tt.msg)
+		if got := v.selectedID(); got != tt.selected {
+			t.Errorf("%s: selected #%d, want #%d", tt.name, got, tt.selected)
+		}
+		if len(v.visible) != len(tt.msg.items) {
+			t.Errorf("%s: %d rows, want %d", tt.name, len(v.visible), len(tt.msg.items))
+		}
+	}
+}
@@ -0,0 +1,433 @@
+package views
+
+import (
+	"fazure/azure"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// boardFinishedDays is how many days finished work items stay on the board after their last change
+const boardFinishedDays = 30
+
+// boardLoadedMsg is sent when a board of the team and its work items have been fetched
+type boardLoadedMsg struct {
+	boards []string
+	board  *azure.Board
+	items  []azure.WorkItem
+	err    error
+}
+
+// boardCardMovedMsg is sent when a card has been moved to another column of the board
+type boardCardMovedMsg struct {
+	from string
+	item *azure.WorkItem
+	err  error
+}
+
+// boardSlot is a column of the board, or one half of a split column
+type boardSlot struct {
+	col  int
+	done bool
+}
+
+// BoardView shows the work items of a backlog level on the team's board, with
+// the columns, split columns, swimlanes and WIP limits the team configured
+type BoardView struct {
+	boards    []string
+	current   int
+	board     *azure.Board
+	items     []azure.WorkItem
+	slots     []boardSlot
+	cells     [][][]int
+	lane      int
+	slot      int
+	row       int
+	loading   bool
+	moving    bool
+	err       error
+	status    string
+	statusErr error
+}
+
+func (v *BoardView) Init(m Model) tea.Cmd {
+	return v.load(m)
+}
+
+// load fetches the selected board of the team and the work items shown on it
+func (v *BoardView) load(m Model) tea.Cmd {
+	v.loading = true
+	v.err = nil
+	boards, current := v.boards, v.current
+	return func() tea.Msg {
+		if boards == nil {
+			var err error
+			boards, err = m.azure.GetBoards()
+			if err != nil {
+				return boardLoadedMsg{err: err}
+			}
+			if len(boards) == 0 {
+				return boardLoadedMsg{err: fmt.Errorf("the team has no boards")}
+			}
+		}
+
+		board, err := m.azure.GetBoard(boards[current])
+		if err != nil {
+			return boardLoadedMsg{boards: boards, err: err}
+		}
+		areas, err := m.azure.GetTeamAreas()
+		if err != nil {
+			return boardLoadedMsg{boards: boards, err: err}
+		}
+		items, err := m.azure.QueryWorkItems(azure.QueryParams{
+			Types:         board.Types(),
+			ExcludeStates: []string{"Removed"},
+			Areas:         areas,
+			FinishedSince: time.Now().AddDate(0, 0, -boardFinishedDays),
+			OrderBy:       []azure.SortKey{{Field: azure.SortByPriority}, {Field: azure.SortByID}},
+			Fields:        append(slices.Clone(taskboardFields), board.Fields()...),
+		})
+		return boardLoadedMsg{boards: boards, board: board, items: items, err: err}
+	}
+}
+
+// regroup places the cards in their lanes and columns, keeping the selected card under the cursor
+func (v *BoardView) regroup() {
+	selected := v.selected()
+
+	v.slots = nil
+	for col, column := range v.board.Columns {
+		v.slots = append(v.slots, boardSlot{col: col})
+		if column.IsSplit {
+			v.slots = append(v.slots, boardSlot{col: col, done: true})
+		}
+	}
+
+	v.cells = make([][][]int, len(v.board.Lanes))
+	for lane := range v.cells {
+		v.cells[lane] = make([][]int, len(v.slots))
+	}
+	for i, item := range v.items {
+		col, done := v.board.Position(item)
+		if col < 0 {
+			continue
+		}
+		lane := max(slices.Index(v.board.Lanes, v.board.Lane(item)), 0)
+		slot := slices.Index(v.slots, boardSlot{col: col, done: done})
+		v.cells[lane][slot] = append(v.cells[lane][slot], i)
+	}
+
+	v.lane = min(v.lane, len(v.cells)-1)
+	v.slot = min(v.slot, len(v.slots)-1)
+	v.row = 0
+	for lane := range v.cells {
+		for slot, cards := range v.cells[lane] {
+			for row, index := range cards {
+				if selected != nil && v.items[index].ID == selected.ID {
+					v.lane, v.slot, v.row = lane, slot, row
+				}
+			}
+		}
+	}
+}
+
+// selected returns the work item of the card under the cursor, if any
+func (v *BoardView) selected() *azure.WorkItem {
+	if v.lane >= len(v.cells) || v.slot >= len(v.cells[v.lane]) || v.row >= len(v.cells[v.lane][v.slot]) {
+		return nil
+	}
+	return &v.items[v.cells[v.lane][v.slot][v.row]]
+}
+
+// columnCount returns the number of cards in a column across lanes and both halves
+func (v *BoardView) columnCount(col int) int {
+	count := 0
+	for _, lane := range v.cells {
+		for slot, cards := range lane {
+			if v.slots[slot].col == col {
+				count += len(cards)
+			}
+		}
+	}
+	return count
+}
+
+func (v *BoardView) View(m Model) string {
+	var s strings.Builder
+	title := "Board"
+	if v.board != nil {
+		title += ": " + v.board.Name
+	}
+	s.WriteString(TitleStyle.Render(title))
+	s.WriteString("\n")
+	s.WriteString(CommentDateStyle.Render(v.boardList()))
+	s.WriteString("\n\n")
+
+	switch {
+	case v.err != nil:
+		s.WriteString(errorBanner(v.err, getContentWidth(m.terminalWidth), true))
+		s.WriteString("\n\n")
+		s.WriteString(HelpStyle.Render("Press 'esc' to go back"))
+		return s.String()
+	case v.board == nil:
+		s.WriteString("Loading the board...\n\n")
+		s.WriteString(HelpStyle.Render("Press 'esc' to go back"))
+		return s.String()
+	}
+
+	s.WriteString(v.renderBoard(m))
+	s.WriteString("\n")
+
+	if v.statusErr != nil {
+		s.WriteString(ErrorStyle.Render(describeError(v.statusErr)) + "\n")
+	} else if v.status != "" {
+		s.WriteString(SuccessStyle.Render(v.status) + "\n")
+	}
+	s.WriteString(HelpStyle.Render("Press 'h'/'l' to move a card • arrows to select • 'tab' for the next board • 'enter' to view details • 'r' to refresh • 'esc' for the backlog"))
+	return s.String()
+}
+
+// boardList lists the boards of the team, marking the one shown
+func (v *BoardView) boardList() string {
+	names := make([]string, len(v.boards))
+	for i, name := range v.boards {
+		names[i] = name
+		if i == v.current {
+			names[i] = "[" + name + "]"
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// slotName returns the name of a column, or of one half of a split column
+func (v *BoardView) slotName(slot boardSlot) string {
+	column := v.board.Columns[slot.col]
+	switch {
+	case !column.IsSplit:
+		return column.Name
+	case slot.done:
+		return column.Name + " Done"
+	default:
+		return column.Name + " Doing"
+	}
+}
+
+// slotTitle returns the header of a slot. The first half of a split column is headed
+// by the column, counting the cards of both halves against its WIP limit.
+func (v *BoardView) slotTitle(slot boardSlot) string {
+	column := v.board.Columns[slot.col]
+	if slot.done {
+		return v.slotName(slot)
+	}
+	if column.ItemLimit > 0 {
+		return fmt.Sprintf("%s (%d/%d)", column.Name, v.columnCount(slot.col), column.ItemLimit)
+	}
+	if column.Type == azure.ColumnOutgoing {
+		// Only recently finished work items are loaded
+		return fmt.Sprintf("%s (%d, %dd)", column.Name, v.columnCount(slot.col), boardFinishedDays)
+	}
+	return fmt.Sprintf("%s (%d)", column.Name, v.columnCount(slot.col))
+}
+
+// renderBoard renders the column headers followed by the cards of each lane
+func (v *BoardView) renderBoard(m Model) string {
+	width := max((m.terminalWidth-8)/len(v.slots)-2, 16)
+	// Each card takes three lines, and the lanes share the height of the terminal
+	visible := max((m.terminalHeight-16)/3/len(v.cells), 1)
+	cell := lipgloss.NewStyle().Width(width).MarginRight(2)
+
+	headers := make([]string, len(v.slots))
+	for i, slot := range v.slots {
+		style := HeaderStyle
+		column := v.board.Columns[slot.col]
+		if column.ItemLimit > 0 && v.columnCount(slot.col) > column.ItemLimit {
+			style = WIPExceededStyle
+		}
+		headers[i] = cell.Render(style.Width(width).Render(truncate(v.slotTitle(slot), width-4)))
+	}
+
+	var s strings.Builder
+	s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, headers...))
+	for lane, slots := range v.cells {
+		if len(v.cells) > 1 {
+			name := v.board.Lanes[lane]
+			if name == "" {
+				name = "Default lane"
+			}
+			s.WriteString("\n" + CommentDateStyle.Render("── "+name+" "+strings.Repeat("─", max(m.terminalWidth-16-len(name), 0))))
+		}
+
+		rendered := make([]string, len(slots))
+		for slot, cards := range slots {
+			selected := lane == v.lane && slot == v.slot
+			offset := 0
+			if selected {
+				offset = max(v.row-visible+1, 0)
+			}
+
+			var lines []string
+			if offset > 0 {
+				lines = append(lines, CommentDateStyle.Render(fmt.Sprintf("  ↑ %d more", offset)))
+			}
+			end := min(offset+visible, len(cards))
+			for row := offset; row < end; row++ {
+				lines = append(lines, renderCard(v.items[cards[row]], width, selected && row == v.row)...)
+			}
+			if end < len(cards) {
+				lines = append(lines, CommentDateStyle.Render(fmt.Sprintf("  ↓ %d more", len(cards)-end)))
+			}
+			rendered[slot] = cell.Render(strings.Join(lines, "\n"))
+		}
+		s.WriteString("\n" + lipgloss.JoinHorizontal(lipgloss.Top, rendered...))
+	}
+	return s.String()
+}
+
+func (v *BoardView) Update(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case boardLoadedMsg:
+		v.loading = false
+		v.err = msg.err
+		if msg.boards != nil {
+			v.boards = msg.boards
+		}
+		if msg.err == nil {
+			v.board = msg.board
+			v.items = msg.items
+			v.regroup()
+		}
+		return m, nil
+	case boardCardMovedMsg:
+		v.moved(msg)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.view = &BacklogView{}
+			return m, m.view.Init(m)
+		case "r":
+			v.status, v.statusErr = "", nil
+			return m, v.load(m)
+		case "tab":
+			if len(v.boards) < 2 || v.loading {
+				return m, nil
+			}
+			v.current = (v.current + 1) % len(v.boards)
+			v.board = nil
+			v.lane, v.slot, v.row = 0, 0, 0
+			v.status, v.statusErr = "", nil
+			return m, v.load(m)
+		}
+		if v.err != nil || v.board == nil {
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "left":
+			v.selectSlot(v.slot - 1)
+		case "right":
+			v.selectSlot(v.slot + 1)
+		case "k", "up":
+			v.selectRow(-1)
+		case "j", "down":
+			v.selectRow(1)
+		case "h":
+			return m, v.move(m, -1)
+		case "l":
+			return m, v.move(m, 1)
+		case "enter":
+			if item := v.selected(); item != nil {
+				m.view = &DetailsView{item: item, returnTo: v}
+				return m, m.view.Init(m)
+			}
+		}
+	}
+	return m, nil
+}
+
+// selectSlot moves the cursor to another column, keeping its row where possible
+func (v *BoardView) selectSlot(slot int) {
+	if slot < 0 || slot >= len(v.slots) {
+		return
+	}
+	v.slot = slot
+	v.row = min(v.row, max(len(v.cells[v.lane][slot])-1, 0))
+}
+
+// selectRow moves the cursor up or down a column, continuing in the next lane
+func (v *BoardView) selectRow(dir int) {
+	row := v.row + dir
+	if row >= 0 && row < len(v.cells[v.lane][v.slot]) {
+		v.row = row
+		return
+	}
+	for lane := v.lane + dir; lane >= 0 && lane < len(v.cells); lane += dir {
+		if cards := v.cells[lane][v.slot]; len(cards) > 0 {
+			v.lane = lane
+			v.row = 0
+			if dir < 0 {
+				v.row = len(cards) - 1
+			}
+			return
+		}
+	}
+}
+
+// move moves the selected card to the nearest column in the given direction that
+// has a state for its type, keeping it in its lane
+func (v *BoardView) move(m Model, dir int) tea.Cmd {
+	item := v.selected()
+	if item == nil || v.moving {
+		return nil
+	}
+
+	var ops []azure.PatchOperation
+	var err error
+	target := -1
+	for slot := v.slot + dir; slot >= 0 && slot < len(v.slots); slot += dir {
+		ops, err = v.board.Move(*item, v.slots[slot].col, v.slots[slot].done)
+		if err == nil {
+			target = slot
+			break
+		}
+	}
+	if target < 0 {
+		v.status = ""
+		v.statusErr = fmt.Errorf("%s #%d cannot move any further", item.Type, item.ID)
+		return nil
+	}
+
+	id, rev, from := item.ID, item.Rev, v.slotName(v.slots[v.slot])
+	to := v.slotName(v.slots[target])
+	v.moving = true
+	v.status = fmt.Sprintf("Moving #%d to %s...", id, to)
+	v.statusErr = nil
+	return func() tea.Msg {
+		updated, err := m.azure.UpdateWorkItem(id, rev, ops)
+		return boardCardMovedMsg{from: from, item: updated, err: err}
+	}
+}
+
+// moved shows the outcome of moving a card and puts it in its new column
+func (v *BoardView) moved(msg boardCardMovedMsg) {
+	v.moving = false
+	if msg.err != nil {
+		v.status = ""
+		v.statusErr = msg.err
+		return
+	}
+
+	for i := range v.items {
+		if v.items[i].ID == msg.item.ID {
+			v.items[i] = *msg.item
+		}
+	}
+	// The cursor follows the card to its new column
+	v.regroup()
+	v.status = fmt.Sprintf("#%d %s → %s", msg.item.ID, msg.from, v.slotName(v.slots[v.slot]))
+	v.statusErr = nil
+}
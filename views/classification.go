@@ -0,0 +1,62 @@
+package views
+
+import (
+	"fazure/azure"
+	"fazure/forms"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// classificationLoadedMsg is sent when the area and iteration trees of the project have been fetched
+type classificationLoadedMsg struct {
+	areas      *azure.ClassificationNode
+	iterations *azure.ClassificationNode
+	current    *azure.ClassificationNode
+	err        error
+}
+
+// loadClassification fetches the area and iteration trees and the current iteration of the team
+func loadClassification(m Model) tea.Cmd {
+	return func() tea.Msg {
+		areas, err := m.azure.GetClassificationNodes(azure.Areas)
+		if err != nil {
+			return classificationLoadedMsg{err: err}
+		}
+		iterations, err := m.azure.GetClassificationNodes(azure.Iterations)
+		if err != nil {
+			return classificationLoadedMsg{err: err}
+		}
+		// Without a current iteration the pickers still offer every iteration
+		current, _ := m.azure.GetCurrentIteration()
+		return classificationLoadedMsg{areas: areas, iterations: iterations, current: current}
+	}
+}
+
+// classificationOption converts an area or iteration tree to an option of a tree picker,
+// showing the dates of iterations and marking the current one
+func classificationOption(node, current *azure.ClassificationNode) *forms.TreeOption {
+	option := &forms.TreeOption{Label: node.Name, Value: node.Path}
+	if !node.StartDate.IsZero() {
+		option.Detail = fmt.Sprintf("%s - %s", node.StartDate.Format("Jan 2"), node.FinishDate.Format("Jan 2"))
+	}
+	if current != nil && node.Path == current.Path {
+		option.Detail += " (current)"
+	}
+	for _, child := range node.Children {
+		option.Children = append(option.Children, classificationOption(child, current))
+	}
+	return option
+}
+
+// setClassification fills the area and iteration pickers once the trees have been
+// fetched. Options listed before the trees, e.g. "Any", come first.
+func setClassification(msg classificationLoadedMsg, areaPath, iteration *forms.TreeField, areaFirst, iterationFirst []*forms.TreeOption) {
+	if msg.err != nil {
+		areaPath.SetError(msg.err)
+		iteration.SetError(msg.err)
+		return
+	}
+	areaPath.SetOptions(append(areaFirst, classificationOption(msg.areas, nil)))
+	iteration.SetOptions(append(iterationFirst, classificationOption(msg.iterations, msg.current)))
+}
@@ -0,0 +1,128 @@
+package views
+
+import (
+	"errors"
+	"fazure/config"
+	"fazure/forms"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// columnPicker chooses the backlog columns. Checked fields are shown in the
+// order they are listed; any other field can be added by its reference name.
+type columnPicker struct {
+	form    *forms.Form
+	columns *forms.CheckboxField
+	add     *forms.TextInputField
+	fields  map[string]string
+	err     error
+}
+
+func newColumnPicker(columns []config.Column) *columnPicker {
+	p := &columnPicker{fields: map[string]string{}}
+
+	var options, checked []string
+	option := func(col config.Column) string {
+		label := columnTitle(col)
+		if _, ok := p.fields[label]; ok {
+			label = col.Field
+		}
+		p.fields[label] = col.Field
+		options = append(options, label)
+		return label
+	}
+	for _, col := range columns {
+		checked = append(checked, option(col))
+	}
+	for _, field := range knownColumnFields {
+		if !slices.ContainsFunc(columns, func(c config.Column) bool { return c.Field == field }) {
+			option(config.Column{Field: field})
+		}
+	}
+
+	p.columns = forms.NewCheckboxField("Columns", options, checked)
+	p.add = forms.NewTextInputField("Add Field", "", "Reference name, e.g. Microsoft.VSTS.Scheduling.Effort")
+	p.form = forms.NewForm(p.columns, p.add)
+	return p
+}
+
+// selected returns the chosen columns, keeping the titles of current columns
+func (p *columnPicker) selected(current []config.Column) []config.Column {
+	var columns []config.Column
+	for _, label := range p.columns.Checked() {
+		field := p.fields[label]
+		col := config.Column{Field: field}
+		if i := slices.IndexFunc(current, func(c config.Column) bool { return c.Field == field }); i >= 0 {
+			col = current[i]
+		}
+		columns = append(columns, col)
+	}
+
+	if field := strings.TrimSpace(p.add.Value()); field != "" &&
+		!slices.ContainsFunc(columns, func(c config.Column) bool { return c.Field == field }) {
+		columns = append(columns, config.Column{Field: field})
+	}
+	return columns
+}
+
+func (p *columnPicker) View(m Model) string {
+	var s strings.Builder
+	s.WriteString(TextAreaHeader.Render("Columns"))
+	s.WriteString("\n")
+	s.WriteString(p.form.View())
+	s.WriteString("\n")
+	if p.err != nil {
+		s.WriteString(errorBanner(p.err, getContentWidth(m.terminalWidth), false))
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// updatePicker handles input while the column picker is open
+func (v *BacklogView) updatePicker(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !v.picker.form.IsEditing {
+		switch msg.String() {
+		case "c", "esc":
+			v.picker = nil
+			return m, nil
+		}
+	}
+
+	_, cmd := v.picker.form.Update(m, msg)
+	if v.picker.form.IsEditing {
+		return m, cmd
+	}
+
+	columns := v.picker.selected(v.columns)
+	if slices.Equal(columns, v.columns) {
+		return m, cmd
+	}
+	if len(columns) == 0 {
+		v.picker = newColumnPicker(v.columns)
+		v.picker.err = errors.New("at least one column must be shown")
+		return m, cmd
+	}
+
+	added := v.picker.add.Value() != ""
+	v.columns = columns
+	m.config.Columns = columns
+	v.sortKeys = slices.DeleteFunc(slices.Clone(v.sortKeys), func(k columnSort) bool {
+		return !slices.ContainsFunc(columns, func(c config.Column) bool { return c.Field == k.field })
+	})
+	m.sort = v.sortKeys
+
+	err := m.config.Save()
+	if added {
+		// List the new field with the other columns
+		v.picker = newColumnPicker(columns)
+	}
+	v.picker.err = nil
+	if err != nil {
+		v.picker.err = fmt.Errorf("columns changed for this session only: %w", err)
+	}
+	v.refreshRows()
+	return m, tea.Batch(cmd, v.load(m))
+}
@@ -0,0 +1,169 @@
+package views
+
+import (
+	"fazure/azure"
+	"fazure/config"
+	"fazure/markup"
+	"slices"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// minColumnWidth is the narrowest a backlog column is shrunk to fit the terminal
+	minColumnWidth = 6
+	// maxColumnWidth is the widest a backlog column grows to fit its content
+	maxColumnWidth = 60
+)
+
+// fieldLabels maps work item field reference names to display labels
+var fieldLabels = map[string]string{
+	azure.FieldID:                 "ID",
+	azure.FieldWorkItemType:       "Type",
+	azure.FieldTitle:              "Title",
+	azure.FieldAssignedTo:         "Assigned To",
+	azure.FieldState:              "State",
+	azure.FieldPriority:           "Priority",
+	azure.FieldDescription:        "Description",
+	azure.FieldAcceptanceCriteria: "Acceptance Criteria",
+	azure.FieldCreatedBy:          "Created By",
+	azure.FieldCreatedDate:        "Created Date",
+	azure.FieldChangedDate:        "Changed Date",
+	azure.FieldTags:               "Tags",
+	azure.FieldAreaPath:           "Area Path",
+	azure.FieldIterationPath:      "Iteration Path",
+	azure.FieldStoryPoints:        "Story Points",
+	azure.FieldOriginalEstimate:   "Original Estimate",
+	azure.FieldRemainingWork:      "Remaining Work",
+	azure.FieldCompletedWork:      "Completed Work",
+}
+
+// fieldLabel returns the display label for a field reference name
+func fieldLabel(ref string) string {
+	if label, ok := fieldLabels[ref]; ok {
+		return label
+	}
+	return ref
+}
+
+// htmlFields are the fields holding rich text as HTML
+var htmlFields = []string{
+	azure.FieldDescription,
+	azure.FieldAcceptanceCriteria,
+	"Microsoft.VSTS.TCM.ReproSteps",
+	"Microsoft.VSTS.TCM.SystemInfo",
+}
+
+// fieldText returns the value of a field as plain text, converting rich text from HTML
+func fieldText(ref, value string) string {
+	if slices.Contains(htmlFields, ref) {
+		return markup.ToPlain(value)
+	}
+	return value
+}
+
+// defaultColumns are shown in the backlog when the config file does not list any
+var defaultColumns = []config.Column{
+	{Field: azure.FieldID},
+	{Field: azure.FieldWorkItemType},
+	{Field: azure.FieldTitle},
+	{Field: azure.FieldAssignedTo},
+	{Field: azure.FieldState},
+	{Field: azure.FieldPriority},
+}
+
+// knownColumnFields are offered by the column picker in addition to the configured columns
+var knownColumnFields = []string{
+	azure.FieldID,
+	azure.FieldWorkItemType,
+	azure.FieldTitle,
+	azure.FieldAssignedTo,
+	azure.FieldState,
+	azure.FieldPriority,
+	azure.FieldStoryPoints,
+	azure.FieldOriginalEstimate,
+	azure.FieldRemainingWork,
+	azure.FieldCompletedWork,
+	azure.FieldTags,
+	azure.FieldIterationPath,
+	azure.FieldAreaPath,
+	azure.FieldCreatedBy,
+	azure.FieldCreatedDate,
+	azure.FieldChangedDate,
+}
+
+// searchFields are fetched for every backlog query because the search and the
+// filter panel rely on them
+var searchFields = []string{
+	azure.FieldTitle,
+	azure.FieldAssignedTo,
+	azure.FieldTags,
+	azure.FieldState,
+}
+
+// backlogColumns returns the configured backlog columns
+func backlogColumns(cfg config.Config) []config.Column {
+	if len(cfg.Columns) == 0 {
+		return defaultColumns
+	}
+	return cfg.Columns
+}
+
+// columnTitle returns the header of a column
+func columnTitle(col config.Column) string {
+	if col.Title != "" {
+		return col.Title
+	}
+	return fieldLabel(col.Field)
+}
+
+// backlogFields returns the fields to fetch for the given columns
+func backlogFields(columns []config.Column) []string {
+	var fields []string
+	for _, col := range columns {
+		fields = append(fields, col.Field)
+	}
+	for _, field := range searchFields {
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// fitColumns returns column widths that fit the titles and rows in the given width.
+// Columns get the width of their content; when that is too wide, the widest columns
+// are shrunk first.
+func fitColumns(titles []string, rows [][]string, width int) []int {
+	widths := make([]int, len(titles))
+	for i, title := range titles {
+		widths[i] = ansi.StringWidth(title)
+	}
+	for _, row := range rows {
+		for i := range widths {
+			if i < len(row) {
+				widths[i] = max(widths[i], ansi.StringWidth(row[i]))
+			}
+		}
+	}
+	for i := range widths {
+		widths[i] = min(widths[i], maxColumnWidth)
+	}
+
+	// Lower a common cap on the widths until they fit
+	total := func(limit int) int {
+		sum := 0
+		for _, w := range widths {
+			sum += min(w, limit)
+		}
+		return sum
+	}
+	limit := slices.Max(append(widths, 0))
+	for limit > minColumnWidth && total(limit) > width {
+		limit--
+	}
+	for i := range widths {
+		widths[i] = min(widths[i], limit)
+	}
+	return widths
+}
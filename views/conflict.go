@@ -0,0 +1,208 @@
+package views
+
+import (
+	"fazure/azure"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// conflictField is a pending edit that has to be reconciled with the server's version
+type conflictField struct {
+	op       azure.PatchOperation
+	ref      string
+	original string
+	mine     string
+	theirs   string
+	keepMine bool
+}
+
+// ConflictView lets the user reconcile pending edits with a newer server revision
+type ConflictView struct {
+	details *DetailsView
+	latest  *azure.WorkItem
+	fields  []conflictField
+	cursor  int
+	saving  bool
+	err     error
+}
+
+func newConflictView(details *DetailsView, latest *azure.WorkItem, ops []azure.PatchOperation) *ConflictView {
+	v := &ConflictView{details: details}
+	for _, op := range ops {
+		ref, ok := strings.CutPrefix(op.Path, "/fields/")
+		if !ok {
+			v.fields = append(v.fields, conflictField{
+				op:       op,
+				ref:      "Links",
+				mine:     describeLinkOp(op, details.item.Relations),
+				keepMine: true,
+			})
+			continue
+		}
+		v.fields = append(v.fields, conflictField{
+			op:       op,
+			ref:      ref,
+			original: details.item.Field(ref),
+			mine:     fmt.Sprint(op.Value),
+			keepMine: true,
+		})
+	}
+	v.setLatest(latest)
+	return v
+}
+
+func (v *ConflictView) Init(m Model) tea.Cmd {
+	return nil
+}
+
+func (v *ConflictView) View(m Model) string {
+	item := v.details.item
+
+	var s strings.Builder
+	s.WriteString(TitleStyle.Render(fmt.Sprintf("Conflict on %s #%d", item.Type, item.ID)))
+	s.WriteString("\n")
+	s.WriteString(wrapText(fmt.Sprintf(
+		"Someone else saved this work item while you were editing it (revision %d → %d).",
+		item.Rev, v.latest.Rev), getContentWidth(m.terminalWidth)))
+	s.WriteString("\n\n")
+
+	width := max((m.terminalWidth-24)/3, 12)
+	cell := func(text string, style lipgloss.Style) string {
+		if text == "" {
+			text = "(empty)"
+		}
+		return style.Width(width).MaxWidth(width).Render(truncate(strings.Join(strings.Fields(text), " "), width-1))
+	}
+
+	s.WriteString(FieldLabelStyle.Render("  Field"))
+	s.WriteString(cell("Original", FieldLabelStyle))
+	s.WriteString(cell("Yours", FieldLabelStyle))
+	s.WriteString(cell("Server", FieldLabelStyle))
+	s.WriteString("\n")
+
+	for i, f := range v.fields {
+		cursor := "  "
+		if i == v.cursor {
+			cursor = "▶ "
+		}
+		mineStyle, theirsStyle := ActiveOptionStyle, InactiveOptionStyle
+		if !f.keepMine {
+			mineStyle, theirsStyle = InactiveOptionStyle, ActiveOptionStyle
+		}
+		label := fieldLabel(f.ref)
+		if f.theirs != f.original {
+			label += " *"
+		}
+
+		s.WriteString(cursor)
+		s.WriteString(FieldValueStyle.Width(13).Render(truncate(label, 13)))
+		s.WriteString(cell(fieldText(f.ref, f.original), InactiveOptionStyle))
+		s.WriteString(cell(fieldText(f.ref, f.mine), mineStyle))
+		s.WriteString(cell(fieldText(f.ref, f.theirs), theirsStyle))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(CommentDateStyle.Render("* changed on the server"))
+	s.WriteString("\n")
+
+	if v.err != nil {
+		s.WriteString("\n" + errorBanner(v.err, getContentWidth(m.terminalWidth), false) + "\n")
+	} else if v.saving {
+		s.WriteString("\n" + SuccessStyle.Render("Saving...") + "\n")
+	}
+
+	s.WriteString(HelpStyle.Render("'space' to pick yours/server • 'enter' to save merge • 'r' to re-apply all yours • 'd' to discard yours • 'esc' to go back"))
+	return s.String()
+}
+
+func (v *ConflictView) Update(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case workItemSavedMsg:
+		if msg.id != v.latest.ID {
+			return m, nil
+		}
+		v.saving = false
+		if msg.err != nil {
+			v.err = msg.err
+			return m, nil
+		}
+		return v.resolve(m, msg.item, "Saved")
+	case revisionConflictMsg:
+		if msg.id != v.latest.ID {
+			return m, nil
+		}
+		v.saving = false
+		v.setLatest(msg.latest)
+		return m, nil
+	case tea.KeyMsg:
+		if v.saving {
+			return m, nil
+		}
+		switch msg.String() {
+		case "j", "down":
+			if v.cursor < len(v.fields)-1 {
+				v.cursor++
+			}
+		case "k", "up":
+			if v.cursor > 0 {
+				v.cursor--
+			}
+		case " ", "h", "l", "left", "right":
+			v.fields[v.cursor].keepMine = !v.fields[v.cursor].keepMine
+		case "r":
+			for i := range v.fields {
+				v.fields[i].keepMine = true
+			}
+			return v.apply(m)
+		case "enter":
+			return v.apply(m)
+		case "d":
+			return v.resolve(m, v.latest, "Discarded your changes")
+		case "esc":
+			m.view = v.details
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// setLatest records the newest server version of the work item
+func (v *ConflictView) setLatest(latest *azure.WorkItem) {
+	v.latest = latest
+	for i := range v.fields {
+		v.fields[i].theirs = latest.Field(v.fields[i].ref)
+	}
+}
+
+// apply saves the chosen edits on top of the latest server revision
+func (v *ConflictView) apply(m Model) (tea.Model, tea.Cmd) {
+	var ops []azure.PatchOperation
+	for _, f := range v.fields {
+		if f.keepMine {
+			ops = append(ops, f.op)
+		}
+	}
+	// Removed links are addressed by index, which may differ in the latest revision
+	ops = rebaseLinkOps(ops, v.details.item.Relations, v.latest.Relations)
+	if len(ops) == 0 {
+		return v.resolve(m, v.latest, "Discarded your changes")
+	}
+
+	v.saving = true
+	v.err = nil
+	return m, updateWorkItem(m, v.latest.ID, v.latest.Rev, ops)
+}
+
+// resolve returns to the details of the given work item version
+func (v *ConflictView) resolve(m Model, item *azure.WorkItem, status string) (tea.Model, tea.Cmd) {
+	v.details.replaceItem(item)
+	cmd := v.details.Init(m)
+	v.details.status = status
+	v.details.err = nil
+	m.view = v.details
+	return m, cmd
+}
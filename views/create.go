@@ -0,0 +1,232 @@
+package views
+
+import (
+	"errors"
+	"fazure/azure"
+	"fazure/forms"
+	"fazure/markup"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const noParent = "None"
+
+// projectDefault stands for leaving the area or iteration to the project's default
+const projectDefault = "Project default"
+
+// workItemTypesLoadedMsg is sent when the work item types of the project have been fetched
+type workItemTypesLoadedMsg struct {
+	types []azure.WorkItemType
+	err   error
+}
+
+// workItemCreatedMsg is sent when a new work item has been created
+type workItemCreatedMsg struct {
+	item *azure.WorkItem
+	err  error
+}
+
+// CreateView is a form for creating a new work item, optionally as a child of
+// the work item that was selected in the backlog
+type CreateView struct {
+	parent *azure.WorkItem
+
+	form        *forms.Form
+	itemType    *forms.RadioField
+	title       *forms.TextInputField
+	description *forms.TextAreaField
+	assignedTo  *forms.TypeaheadField
+	areaPath    *forms.TreeField
+	iteration   *forms.TreeField
+	priority    *forms.RadioField
+	tags        *forms.TextInputField
+	parentLink  *forms.RadioField
+
+	creating bool
+	err      error
+}
+
+func (v *CreateView) Init(m Model) tea.Cmd {
+	return func() tea.Msg {
+		types, err := m.azure.GetWorkItemTypes()
+		return workItemTypesLoadedMsg{types: types, err: err}
+	}
+}
+
+// build creates the form once the work item types are known
+func (v *CreateView) build(m Model, types []azure.WorkItemType) {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	v.itemType = forms.NewRadioField("Type", names, true)
+	v.itemType.Select(string(azure.Task))
+
+	var areaPath, iteration string
+	parents := []string{noParent}
+	if v.parent != nil {
+		areaPath, iteration = v.parent.AreaPath, v.parent.Iteration
+		parents = append(parents, parentOption(v.parent))
+	}
+
+	v.title = forms.NewTextInputField("Title", "", "Required")
+	v.description = forms.NewTextAreaField("Description", "", false)
+	v.assignedTo = newAssigneeField(m, forms.Suggestion{Label: m.user, Value: m.user})
+	v.areaPath = forms.NewTreeField("Area Path", areaPath, projectDefault)
+	v.iteration = forms.NewTreeField("Iteration", iteration, projectDefault)
+	v.priority = forms.NewRadioField("Priority", slices.Clone(priorityOptions), true)
+	v.priority.Select("2")
+	v.tags = forms.NewTextInputField("Tags", "", "Comma separated")
+	v.parentLink = forms.NewRadioField("Parent", parents, true)
+
+	v.form = forms.NewForm(
+		v.itemType,
+		v.title,
+		v.assignedTo,
+		v.priority,
+		v.areaPath,
+		v.iteration,
+		v.tags,
+		v.parentLink,
+		v.description,
+	)
+}
+
+// parentOption describes a work item in the parent picker
+func parentOption(item *azure.WorkItem) string {
+	return fmt.Sprintf("%s #%d %s", item.Type, item.ID, truncate(item.Title, 40))
+}
+
+func (v *CreateView) View(m Model) string {
+	var s strings.Builder
+	s.WriteString(TitleStyle.Render("New Work Item"))
+	s.WriteString("\n\n")
+
+	if v.form == nil {
+		if v.err != nil {
+			s.WriteString(errorBanner(v.err, getContentWidth(m.terminalWidth), false))
+			s.WriteString("\n\n")
+		} else {
+			s.WriteString("Loading work item types...\n\n")
+		}
+		s.WriteString(HelpStyle.Render("Press 'esc' to cancel"))
+		return s.String()
+	}
+
+	s.WriteString(v.form.View())
+	if v.err != nil {
+		s.WriteString("\n" + errorBanner(v.err, getContentWidth(m.terminalWidth), false) + "\n")
+	} else if v.creating {
+		s.WriteString("\n" + SuccessStyle.Render("Creating...") + "\n")
+	}
+	s.WriteString(HelpStyle.Render("Press 'enter' to edit a field • 'ctrl+s' to create • 'esc' to cancel"))
+	return s.String()
+}
+
+func (v *CreateView) Update(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case workItemTypesLoadedMsg:
+		types := msg.types
+		if msg.err != nil || len(types) == 0 {
+			// Fall back to the types of the built-in processes
+			v.err = msg.err
+			types = knownTypes
+		}
+		v.build(m, types)
+		return m, loadClassification(m)
+	case classificationLoadedMsg:
+		if v.form != nil {
+			defaults := func() []*forms.TreeOption {
+				return []*forms.TreeOption{{Label: projectDefault}}
+			}
+			setClassification(msg, v.areaPath, v.iteration, defaults(), defaults())
+		}
+		return m, nil
+	case workItemCreatedMsg:
+		v.creating = false
+		if msg.err != nil {
+			v.err = msg.err
+			return m, nil
+		}
+		m.view = &DetailsView{item: msg.item, status: "Created"}
+		return m, m.view.Init(m)
+	}
+
+	if v.form != nil && v.form.IsEditing {
+		_, cmd := v.form.Update(m, msg)
+		return m, cmd
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.view = &BacklogView{}
+			return m, m.view.Init(m)
+		case "ctrl+s":
+			if v.form == nil || v.creating {
+				return m, nil
+			}
+			return m, v.create(m)
+		}
+	}
+
+	if v.form == nil {
+		return m, nil
+	}
+	_, cmd := v.form.Update(m, msg)
+	return m, cmd
+}
+
+// create posts the new work item
+func (v *CreateView) create(m Model) tea.Cmd {
+	title := strings.TrimSpace(v.title.Value())
+	if title == "" {
+		v.err = errors.New("a title is required")
+		return nil
+	}
+
+	ops := []azure.PatchOperation{azure.SetField(azure.FieldTitle, title)}
+	optional := []struct {
+		ref   string
+		value string
+	}{
+		{azure.FieldDescription, markup.FromMarkdown(strings.TrimSpace(v.description.Value()))},
+		{azure.FieldAssignedTo, v.assignedTo.Value()},
+		{azure.FieldAreaPath, v.areaPath.Value()},
+		{azure.FieldIterationPath, v.iteration.Value()},
+		{azure.FieldTags, joinTags(v.tags.Value())},
+	}
+	for _, f := range optional {
+		if f.value != "" {
+			ops = append(ops, azure.SetField(f.ref, f.value))
+		}
+	}
+	priority, _ := strconv.Atoi(v.priority.Value())
+	ops = append(ops, azure.SetField(azure.FieldPriority, priority))
+	if v.parent != nil && v.parentLink.Value() != noParent {
+		ops = append(ops, azure.AddRelation(azure.RelParent, m.azure.WorkItemURL(v.parent.ID), ""))
+	}
+
+	v.creating = true
+	v.err = nil
+	itemType := azure.WorkItemType(v.itemType.Value())
+	return func() tea.Msg {
+		item, err := m.azure.CreateWorkItem(itemType, ops)
+		return workItemCreatedMsg{item: item, err: err}
+	}
+}
+
+// joinTags converts comma or semicolon separated tags to the format of the tags field
+func joinTags(text string) string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return strings.Join(tags, "; ")
+}
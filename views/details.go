@@ -1,39 +1,156 @@
 package views
 
 import (
+	"errors"
 	"fazure/azure"
 	"fazure/forms"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-type DetailsView struct {
+const unassigned = "Unassigned"
+
+// fallbackStates are offered when the workflow of the work item type cannot be fetched
+var fallbackStates = []string{"New", "Active", "Resolved", "Closed"}
+
+// priorityOptions are the priorities allowed by the Azure DevOps process templates
+var priorityOptions = []string{"1", "2", "3", "4"}
+
+// workItemLoadedMsg is sent when all fields of the work item and the workflow
+// of its type have been fetched
+type workItemLoadedMsg struct {
+	item     *azure.WorkItem
+	workflow *azure.Workflow
+	err      error
+}
+
+// workItemSavedMsg is sent when an update of the work item has completed
+type workItemSavedMsg struct {
+	id   int
 	item *azure.WorkItem
-	form *forms.Form
+	err  error
+}
+
+// revisionConflictMsg is sent when an update was rejected because the work item
+// has been changed by someone else since it was loaded
+type revisionConflictMsg struct {
+	id     int
+	ops    []azure.PatchOperation
+	latest *azure.WorkItem
+}
+
+// fieldBinding ties an editable form field to the work item field it writes
+type fieldBinding struct {
+	ref   string
+	field interface{ Value() string }
+	saved string
+	value func(string) any
+}
+
+type DetailsView struct {
+	item       *azure.WorkItem
+	loaded     bool
+	workflow   *azure.Workflow
+	form       *forms.Form
+	iteration  *forms.TreeField
+	areaPath   *forms.TreeField
+	bindings   []*fieldBinding
+	discussion *discussionField
+	links      *linksField
+	history    *historyField
+	status     string
+	err        error
+
+	// prev is the details view a linked work item was opened from
+	prev *DetailsView
+	// returnTo is the view the work item was opened from, the backlog when nil
+	returnTo View
 }
 
 func (v *DetailsView) Init(m Model) tea.Cmd {
+	if !v.loaded {
+		return v.load(m)
+	}
+
+	assignedTo := newAssigneeField(m, assigneeSuggestion(v.item))
+	states := fallbackStates
+	if v.workflow != nil {
+		states = v.workflow.StateNames()
+	}
+	state := forms.NewRadioField("State", states, false)
+	state.Select(v.item.State)
+	priority := forms.NewRadioField("Priority", slices.Clone(priorityOptions), true)
+	if v.item.Priority != 0 {
+		priority.Select(strconv.Itoa(v.item.Priority))
+	}
+	v.iteration = forms.NewTreeField("Iteration Path", v.item.Iteration, "")
+	v.areaPath = forms.NewTreeField("Area Path", v.item.AreaPath, "")
+	description := forms.NewRichTextField("", v.item.Description)
+	acceptanceCriteria := forms.NewRichTextField("", v.item.AcceptanceCriteria)
+
+	v.bindings = []*fieldBinding{
+		{ref: azure.FieldAssignedTo, field: assignedTo},
+		{ref: azure.FieldState, field: state},
+		{ref: azure.FieldPriority, field: priority, value: func(s string) any {
+			p, _ := strconv.Atoi(s)
+			return p
+		}},
+		{ref: azure.FieldIterationPath, field: v.iteration},
+		{ref: azure.FieldAreaPath, field: v.areaPath},
+		{ref: azure.FieldDescription, field: description},
+		{ref: azure.FieldAcceptanceCriteria, field: acceptanceCriteria},
+	}
+	for _, b := range v.bindings {
+		b.saved = b.field.Value()
+	}
+	v.discussion = newDiscussionField()
+	v.links = newLinksField(v.item.Relations)
+	id := v.item.ID
+	v.history = newHistoryField(func() tea.Cmd { return loadHistory(m, id) })
+
 	v.form = forms.NewForm(
-		forms.NewRadioField("Assigned To", []string{"Unassigned", "John Doe", "Jane Smith", "Alice Johnson", "Bob Brown"}, true),
-		forms.NewRadioField("State", []string{"New", "Active", "Resolved", "Closed"}, false),
-		forms.NewRadioField("Priority", []string{"1", "2", "3", "4", "5"}, true),
-		forms.NewReadonly("Iteration Path", v.item.Iteration),
-		forms.NewReadonly("Area Path", v.item.AreaPath),
+		assignedTo,
+		state,
+		priority,
+		v.iteration,
+		v.areaPath,
 		forms.NewReadonly("Created By", v.item.CreatedBy),
 		forms.NewReadonly("Created Date", v.item.CreatedDate),
 		forms.NewTabs("",
-			[]string{"Description", "Acceptance Criteria", "Discussion"},
+			[]string{"Description", "Acceptance Criteria", "Discussion", "Links", "History"},
 			[]forms.FormField{
-				forms.NewTextAreaField("", v.item.Description, true),
-				forms.NewTextAreaField("", v.item.AcceptanceCriteria, true),
-				forms.NewTextAreaField("", "Discussion notes", true),
+				description,
+				acceptanceCriteria,
+				v.discussion,
+				v.links,
+				v.history,
 			},
 		),
 	)
+	v.form.OnSave = func(forms.FormField) tea.Cmd {
+		return tea.Batch(v.save(m), v.comment(m), v.follow(), v.compare(m))
+	}
+
+	return tea.Batch(loadComments(m, v.item.ID), loadLinkTargets(m, v.item.ID, v.item.Relations), loadClassification(m))
+}
 
-	return nil
+// load fetches all fields of the work item, as the backlog only fetches the fields it shows
+func (v *DetailsView) load(m Model) tea.Cmd {
+	v.err = nil
+	id := v.item.ID
+	return func() tea.Msg {
+		item, err := m.azure.GetWorkItem(id)
+		if err != nil {
+			return workItemLoadedMsg{err: err}
+		}
+		// Without a workflow the form falls back to common states
+		workflow, _ := m.azure.GetWorkflow(item.Type)
+		return workItemLoadedMsg{item: item, workflow: workflow}
+	}
 }
 
 func (v *DetailsView) View(m Model) string {
@@ -53,11 +170,121 @@ func (v *DetailsView) View(m Model) string {
 	s.WriteString(GetWorkItemTypeStyle(item.Type).Render(wrappedTitle))
 	s.WriteString("\n\n")
 
+	if v.form == nil {
+		if v.err != nil {
+			s.WriteString(errorBanner(v.err, getContentWidth(m.terminalWidth), true))
+		} else {
+			s.WriteString("Loading work item...")
+		}
+		s.WriteString("\n\n")
+		s.WriteString(HelpStyle.Render("Press 'esc' to go back"))
+		return s.String()
+	}
+
 	s.WriteString(v.form.View())
+
+	if v.err != nil {
+		s.WriteString("\n" + errorBanner(v.err, getContentWidth(m.terminalWidth), false))
+	} else if v.status != "" {
+		s.WriteString("\n" + SuccessStyle.Render(v.status))
+	}
 	return s.String()
 }
 
 func (v *DetailsView) Update(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case workItemLoadedMsg:
+		if msg.err != nil {
+			v.err = msg.err
+			return m, nil
+		}
+		v.replaceItem(msg.item)
+		v.workflow = msg.workflow
+		v.loaded = true
+		return m, v.Init(m)
+	case workItemSavedMsg:
+		// A save can complete after a linked work item has been opened
+		if msg.id != v.item.ID {
+			return m, nil
+		}
+		v.saved(msg)
+		if msg.err != nil {
+			return m, nil
+		}
+		v.links.setRelations(v.item.Relations)
+		return m, tea.Batch(loadLinkTargets(m, v.item.ID, v.item.Relations), v.history.reload())
+	case revisionConflictMsg:
+		if msg.id != v.item.ID {
+			return m, nil
+		}
+		v.status = ""
+		m.view = newConflictView(v, msg.latest, msg.ops)
+		return m, nil
+	case commentsLoadedMsg:
+		if msg.id == v.item.ID {
+			v.discussion.loading = false
+			v.discussion.err = msg.err
+			if msg.err == nil {
+				v.item.Comments = msg.comments
+				v.discussion.comments = msg.comments
+				v.history.comments = msg.comments
+			}
+		}
+		return m, nil
+	case historyLoadedMsg:
+		if msg.id == v.item.ID {
+			v.history.setUpdates(msg.updates, msg.err)
+		}
+		return m, nil
+	case revisionsLoadedMsg:
+		if msg.id == v.item.ID {
+			v.history.setRevisions(msg.revisions, msg.err)
+		}
+		return m, nil
+	case linkTargetsLoadedMsg:
+		if msg.id == v.item.ID {
+			v.links.loading = false
+			v.links.err = msg.err
+			for _, item := range msg.items {
+				v.links.targets[item.ID] = item
+			}
+		}
+		return m, nil
+	case classificationLoadedMsg:
+		if v.form != nil {
+			setClassification(msg, v.areaPath, v.iteration, nil, nil)
+		}
+		return m, nil
+	case openLinkMsg:
+		next := &DetailsView{item: &msg.item, prev: v}
+		m.view = next
+		return m, next.Init(m)
+	case commentPostedMsg:
+		if msg.id == v.item.ID {
+			v.discussion.posting = false
+			v.discussion.err = msg.err
+			if msg.err == nil {
+				v.item.Comments = append(v.item.Comments, *msg.comment)
+				v.discussion.comments = v.item.Comments
+				v.history.comments = v.item.Comments
+				v.discussion.input.SetValue("")
+			}
+		}
+		return m, nil
+	}
+
+	if v.form == nil {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch msg.String() {
+			case "r":
+				return m, v.load(m)
+			case "esc":
+				return v.back(m)
+			}
+		}
+		return m, nil
+	}
+
 	if v.form.IsEditing {
 		_, cmd := v.form.Update(m, msg)
 		return m, cmd
@@ -67,11 +294,131 @@ func (v *DetailsView) Update(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc":
-			m.view = &BacklogView{}
-			return m, m.view.Init(m)
+			return v.back(m)
 		}
 	}
 
 	_, cmd := v.form.Update(m, msg)
 	return m, cmd
 }
+
+// back returns to the work item this one was opened from, or to the view it was opened from
+func (v *DetailsView) back(m Model) (tea.Model, tea.Cmd) {
+	if v.prev != nil {
+		// Reload the previous work item, as links may have changed on either side
+		v.prev.loaded = false
+		v.prev.form = nil
+		m.view = v.prev
+		return m, v.prev.Init(m)
+	}
+	if v.returnTo != nil {
+		// Reload the view, as the work item may have changed
+		m.view = v.returnTo
+		return m, m.view.Init(m)
+	}
+	m.view = &BacklogView{}
+	return m, m.view.Init(m)
+}
+
+// changes returns patch operations for the fields edited since the last save
+func (v *DetailsView) changes() []azure.PatchOperation {
+	var ops []azure.PatchOperation
+	for _, b := range v.bindings {
+		value := b.field.Value()
+		if value == b.saved {
+			continue
+		}
+		if b.value != nil {
+			ops = append(ops, azure.SetField(b.ref, b.value(value)))
+		} else {
+			ops = append(ops, azure.SetField(b.ref, value))
+		}
+	}
+	return ops
+}
+
+// save sends the changed fields and links to Azure DevOps
+func (v *DetailsView) save(m Model) tea.Cmd {
+	ops := v.changes()
+	linkOps, err := linkChanges(m, v.links)
+	if err != nil {
+		v.links.err = err
+	}
+	ops = append(ops, linkOps...)
+	if len(ops) == 0 {
+		return nil
+	}
+
+	v.status = "Saving..."
+	v.err = nil
+	return updateWorkItem(m, v.item.ID, v.item.Rev, ops)
+}
+
+// comment posts the comment written in the discussion tab, if any
+func (v *DetailsView) comment(m Model) tea.Cmd {
+	text := v.discussion.draft()
+	if text == "" || v.discussion.posting {
+		return nil
+	}
+
+	v.discussion.posting = true
+	v.discussion.err = nil
+	return postComment(m, v.item.ID, text)
+}
+
+// follow opens the linked work item chosen in the Links tab, if any
+func (v *DetailsView) follow() tea.Cmd {
+	item := v.links.takeOpen()
+	if item == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return openLinkMsg{item: *item}
+	}
+}
+
+// compare fetches the revisions for a comparison chosen in the History tab, if needed
+func (v *DetailsView) compare(m Model) tea.Cmd {
+	if !v.history.fetchRevisions() {
+		return nil
+	}
+	return loadRevisions(m, v.item.ID)
+}
+
+// updateWorkItem sends ops to Azure DevOps, fetching the latest version of the
+// work item when the update conflicts with someone else's change
+func updateWorkItem(m Model, id, rev int, ops []azure.PatchOperation) tea.Cmd {
+	return func() tea.Msg {
+		item, err := m.azure.UpdateWorkItem(id, rev, ops)
+		if errors.Is(err, azure.ErrRevisionConflict) {
+			latest, latestErr := m.azure.GetWorkItem(id)
+			if latestErr == nil {
+				return revisionConflictMsg{id: id, ops: ops, latest: latest}
+			}
+		}
+		return workItemSavedMsg{id: id, item: item, err: err}
+	}
+}
+
+// saved records the outcome of a save, keeping the edits on failure so they can be retried
+func (v *DetailsView) saved(msg workItemSavedMsg) {
+	if msg.err != nil {
+		v.status = ""
+		v.err = msg.err
+		return
+	}
+
+	v.replaceItem(msg.item)
+	for _, b := range v.bindings {
+		b.saved = b.field.Value()
+	}
+	v.status = "Saved"
+	v.err = nil
+}
+
+// replaceItem replaces the displayed work item with a newer version from the server
+func (v *DetailsView) replaceItem(item *azure.WorkItem) {
+	comments := v.item.Comments
+	*v.item = *item
+	v.item.Comments = comments
+}
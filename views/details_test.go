@@ -0,0 +1,25 @@
+package views
+
+import (
+	"fazure/azure"
+	"testing"
+)
+
+// TestDetailsIgnoresOtherWorkItems checks that the outcome of saving or commenting on
+// another work item, such as the one a link was followed from, leaves the shown work item alone
+func TestDetailsIgnoresOtherWorkItems(t *testing.T) {
+	linked := &azure.WorkItem{ID: 2, Rev: 5, Title: "Linked"}
+	v := &DetailsView{item: linked, loaded: true}
+	original := &azure.WorkItem{ID: 1, Rev: 8, Title: "Original"}
+
+	model, _ := v.Update(Model{view: v}, workItemSavedMsg{id: 1, item: original})
+	model, _ = v.Update(model.(Model), revisionConflictMsg{id: 1, latest: original})
+	model, _ = v.Update(model.(Model), commentPostedMsg{id: 1, comment: &azure.Comment{ID: 3, Content: "Done"}})
+
+	if v.item.ID != 2 || v.item.Rev != 5 || v.item.Title != "Linked" || len(v.item.Comments) != 0 || v.status != "" {
+		t.Errorf("shown work item became %+v with status %q", v.item, v.status)
+	}
+	if model.(Model).view != v {
+		t.Errorf("switched to %T", model.(Model).view)
+	}
+}
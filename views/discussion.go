@@ -0,0 +1,129 @@
+package views
+
+import (
+	"fazure/azure"
+	"fazure/forms"
+	"fazure/markup"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const discussionWidth = 50
+
+// commentsLoadedMsg is sent when the discussion of a work item has been fetched
+type commentsLoadedMsg struct {
+	id       int
+	comments []azure.Comment
+	err      error
+}
+
+// commentPostedMsg is sent when a new comment has been posted
+type commentPostedMsg struct {
+	id      int
+	comment *azure.Comment
+	err     error
+}
+
+// discussionField implements forms.FormField for the discussion of a work item,
+// listing its comments above a text area for writing a new one.
+type discussionField struct {
+	comments []azure.Comment
+	loading  bool
+	posting  bool
+	err      error
+	input    *forms.TextAreaField
+}
+
+func newDiscussionField() *discussionField {
+	return &discussionField{
+		loading: true,
+		input:   forms.NewTextAreaField("New comment", "", false),
+	}
+}
+
+// draft returns the comment being written, if any
+func (d *discussionField) draft() string {
+	return strings.TrimSpace(d.input.Value())
+}
+
+func (d *discussionField) Update(form *forms.Form, msg tea.Msg) tea.Cmd {
+	return d.input.Update(form, msg)
+}
+
+func (d *discussionField) View(form *forms.Form) string {
+	var s strings.Builder
+	s.WriteString("\n")
+
+	switch {
+	case d.loading:
+		s.WriteString(CommentDateStyle.Render("Loading comments..."))
+		s.WriteString("\n")
+	case len(d.comments) == 0:
+		s.WriteString(CommentDateStyle.Render("No comments yet."))
+		s.WriteString("\n")
+	}
+
+	for _, c := range d.comments {
+		header := CommentAuthorStyle.Render(c.Author) + " " + CommentDateStyle.Render(c.Date)
+		body := markup.Render(c.Content, discussionWidth)
+		s.WriteString(CommentStyle.Width(discussionWidth + 4).Render(header + "\n" + body))
+		s.WriteString("\n")
+	}
+
+	if d.err != nil {
+		s.WriteString(ErrorStyle.Render(wrapText(describeError(d.err), discussionWidth)))
+		s.WriteString("\n")
+	}
+	if d.posting {
+		s.WriteString(SuccessStyle.Render("Posting comment..."))
+		s.WriteString("\n")
+	}
+
+	s.WriteString(d.input.View(form))
+	return s.String()
+}
+
+func (d *discussionField) Label() string {
+	return ""
+}
+
+func (d *discussionField) Terminator() string {
+	return d.input.Terminator()
+}
+
+func (d *discussionField) Focus() tea.Cmd {
+	return d.input.Focus()
+}
+
+func (d *discussionField) Blur() {
+	d.input.Blur()
+}
+
+func (d *discussionField) Edit() tea.Cmd {
+	return d.input.Edit()
+}
+
+func (d *discussionField) Save() {
+	d.input.Save()
+}
+
+func (d *discussionField) Cancel() {
+	d.input.Cancel()
+}
+
+// loadComments fetches the discussion of a work item
+func loadComments(m Model, id int) tea.Cmd {
+	return func() tea.Msg {
+		comments, err := m.azure.GetComments(id)
+		return commentsLoadedMsg{id: id, comments: comments, err: err}
+	}
+}
+
+// postComment adds a comment to the discussion of a work item
+func postComment(m Model, id int, text string) tea.Cmd {
+	return func() tea.Msg {
+		comment, err := m.azure.AddComment(id, text)
+		return commentPostedMsg{id: id, comment: comment, err: err}
+	}
+}
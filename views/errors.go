@@ -0,0 +1,58 @@
+package views
+
+import (
+	"errors"
+	"fazure/azure"
+	"fmt"
+	"strings"
+)
+
+// describeError returns a user facing explanation of an error from the work item service
+func describeError(err error) string {
+	var apiErr *azure.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	var hint string
+	switch apiErr.Kind {
+	case azure.ErrorAuth:
+		hint = "Authentication failed. Check that AZURE_PAT is valid and allowed to read work items."
+	case azure.ErrorNotFound:
+		hint = "Not found. Check AZURE_ORG and AZURE_PROJECT."
+	case azure.ErrorThrottled:
+		hint = "Azure DevOps is throttling requests."
+		if apiErr.RetryAfter > 0 {
+			hint = fmt.Sprintf("Azure DevOps is throttling requests. Try again in %s.", apiErr.RetryAfter)
+		}
+	case azure.ErrorServer:
+		hint = "Azure DevOps reported a server error. Try again later."
+	case azure.ErrorNetwork:
+		hint = "Could not reach Azure DevOps. Check your network connection."
+	case azure.ErrorConflict:
+		hint = "The work item was changed by someone else."
+	case azure.ErrorValidation:
+		hint = "Azure DevOps rejected the request."
+	default:
+		hint = "The request to Azure DevOps failed."
+	}
+
+	if apiErr.Message == "" {
+		return hint
+	}
+	return hint + "\n" + apiErr.Message
+}
+
+// errorBanner renders an error as a banner, optionally offering to retry
+func errorBanner(err error, width int, retry bool) string {
+	lines := strings.Split(describeError(err), "\n")
+	for i, line := range lines {
+		lines[i] = wrapText(line, width)
+	}
+
+	text := ErrorStyle.Render("Error") + "\n" + strings.Join(lines, "\n")
+	if retry {
+		text += "\n" + HelpStyle.Render("Press 'r' to retry")
+	}
+	return ErrorBannerStyle.Render(text)
+}
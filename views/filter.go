@@ -0,0 +1,176 @@
+package views
+
+import (
+	"fazure/azure"
+	"fazure/forms"
+	"reflect"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// knownStates are offered by the filter panel in addition to the states of loaded work items
+var knownStates = []string{"New", "Planning", "Active", "In Progress", "Resolved", "Done", "Closed", "Removed"}
+
+// anyPath stands for not filtering by area or iteration
+const anyPath = "Any"
+
+// knownTypes are offered by the filter panel in addition to the types of loaded work items
+var knownTypes = []azure.WorkItemType{azure.Initiative, azure.Requirement, azure.UserStory, azure.Task, azure.Bug}
+
+// filterPanel edits the query behind the backlog.
+// Checked states and types are shown; unchecked ones are excluded from the query.
+type filterPanel struct {
+	form       *forms.Form
+	states     *forms.CheckboxField
+	types      *forms.CheckboxField
+	iteration  *forms.TreeField
+	areaPath   *forms.TreeField
+	assignedTo *forms.TextInputField
+}
+
+func newFilterPanel(params azure.QueryParams, items []azure.WorkItem) *filterPanel {
+	states := slices.Clone(knownStates)
+	types := make([]string, len(knownTypes))
+	for i, t := range knownTypes {
+		types[i] = string(t)
+	}
+	for _, item := range items {
+		if !slices.Contains(states, item.State) {
+			states = append(states, item.State)
+		}
+		if !slices.Contains(types, string(item.Type)) {
+			types = append(types, string(item.Type))
+		}
+	}
+
+	var shownStates, shownTypes []string
+	for _, state := range states {
+		if (len(params.States) == 0 || slices.Contains(params.States, state)) &&
+			!slices.Contains(params.ExcludeStates, state) {
+			shownStates = append(shownStates, state)
+		}
+	}
+	for _, t := range types {
+		itemType := azure.WorkItemType(t)
+		if (len(params.Types) == 0 || slices.Contains(params.Types, itemType)) &&
+			!slices.Contains(params.ExcludeTypes, itemType) {
+			shownTypes = append(shownTypes, t)
+		}
+	}
+
+	p := &filterPanel{
+		states:     forms.NewCheckboxField("States", states, shownStates),
+		types:      forms.NewCheckboxField("Types", types, shownTypes),
+		iteration:  forms.NewTreeField("Iteration", params.IterationPath, anyPath),
+		areaPath:   forms.NewTreeField("Area Path", params.AreaPath, anyPath),
+		assignedTo: forms.NewTextInputField("Assigned To", params.AssignedTo, "Anyone"),
+	}
+	p.form = forms.NewForm(p.states, p.types, p.iteration, p.areaPath, p.assignedTo)
+	return p
+}
+
+// setClassification offers the areas and iterations of the project as filters, which
+// match work items under the chosen path
+func (p *filterPanel) setClassification(msg classificationLoadedMsg) {
+	current := &forms.TreeOption{Label: string(azure.MacroCurrentIteration), Value: string(azure.MacroCurrentIteration)}
+	if msg.current != nil {
+		current.Detail = msg.current.Name
+	}
+	setClassification(msg, p.areaPath, p.iteration,
+		[]*forms.TreeOption{{Label: anyPath}},
+		[]*forms.TreeOption{{Label: anyPath}, current})
+}
+
+// params returns the query described by the panel, keeping the ordering of base
+func (p *filterPanel) params(base azure.QueryParams) azure.QueryParams {
+	params := azure.QueryParams{
+		AssignedTo:    strings.TrimSpace(p.assignedTo.Value()),
+		ExcludeStates: p.states.Unchecked(),
+		Tags:          base.Tags,
+		ExcludeTags:   base.ExcludeTags,
+		IterationPath: p.iteration.Value(),
+		AreaPath:      p.areaPath.Value(),
+		OrderBy:       base.OrderBy,
+	}
+	for _, t := range p.types.Unchecked() {
+		params.ExcludeTypes = append(params.ExcludeTypes, azure.WorkItemType(t))
+	}
+	return params
+}
+
+func (p *filterPanel) View(count int) string {
+	var s strings.Builder
+	s.WriteString(TextAreaHeader.Render("Filters"))
+	s.WriteString("\n")
+	s.WriteString(p.form.View())
+	s.WriteString("\n")
+	s.WriteString(CommentDateStyle.Render(pluralize(count, "matching work item", "matching work items")))
+	s.WriteString("\n")
+	return s.String()
+}
+
+// filterSummary describes the active filters in a single line
+func filterSummary(params azure.QueryParams) string {
+	var parts []string
+	if params.AssignedTo != "" {
+		parts = append(parts, "Assigned to "+params.AssignedTo)
+	} else {
+		parts = append(parts, "Anyone")
+	}
+	if len(params.States) > 0 {
+		parts = append(parts, "States: "+strings.Join(params.States, ", "))
+	}
+	if len(params.ExcludeStates) > 0 {
+		parts = append(parts, "Hiding "+strings.Join(params.ExcludeStates, ", "))
+	}
+	if len(params.Types) > 0 {
+		parts = append(parts, "Types: "+joinTypes(params.Types))
+	}
+	if len(params.ExcludeTypes) > 0 {
+		parts = append(parts, "Hiding "+joinTypes(params.ExcludeTypes))
+	}
+	if len(params.Tags) > 0 {
+		parts = append(parts, "Tags: "+strings.Join(params.Tags, ", "))
+	}
+	if params.IterationPath != "" {
+		parts = append(parts, "Iteration: "+params.IterationPath)
+	}
+	if params.AreaPath != "" {
+		parts = append(parts, "Area: "+params.AreaPath)
+	}
+	return strings.Join(parts, " • ")
+}
+
+func joinTypes(types []azure.WorkItemType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// updateFilter handles input while the filter panel is open
+func (v *BacklogView) updateFilter(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !v.filter.form.IsEditing {
+		switch msg.String() {
+		case "f", "esc":
+			v.filter = nil
+			return m, nil
+		}
+	}
+
+	_, cmd := v.filter.form.Update(m, msg)
+	if v.filter.form.IsEditing {
+		return m, cmd
+	}
+
+	// Re-run the query as soon as a filter has been changed
+	params := v.filter.params(m.query)
+	if reflect.DeepEqual(params, m.query) {
+		return m, cmd
+	}
+	m.query = params
+	return m, tea.Batch(cmd, v.load(m))
+}
@@ -0,0 +1,74 @@
+package views
+
+import (
+	"unicode"
+)
+
+// fuzzyMatch reports whether all runes of pattern appear in text in order,
+// ignoring case. It returns a score that rewards consecutive matches and
+// matches at the start of words, and the rune positions of the match in text.
+func fuzzyMatch(pattern, text string) (int, []int, bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	t := []rune(text)
+	positions := make([]int, 0, len(p))
+	score := 0
+	prev := -2
+
+	for i, r := range t {
+		if len(positions) == len(p) {
+			break
+		}
+		if unicode.ToLower(r) != unicode.ToLower(p[len(positions)]) {
+			continue
+		}
+
+		score++
+		if i == prev+1 {
+			score += 3
+		}
+		if i == 0 || !unicode.IsLetter(t[i-1]) && !unicode.IsDigit(t[i-1]) {
+			score += 2
+		}
+		positions = append(positions, i)
+		prev = i
+	}
+
+	if len(positions) < len(p) {
+		return 0, nil, false
+	}
+	return score - (positions[len(positions)-1] - positions[0] - len(p) + 1), positions, true
+}
+
+// highlightRunes renders the runes at the given positions of text with style
+func highlightRunes(text string, positions []int, render func(string) string) string {
+	if len(positions) == 0 {
+		return text
+	}
+
+	highlighted := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		highlighted[pos] = true
+	}
+
+	var out, run []rune
+	flush := func() {
+		if len(run) > 0 {
+			out = append(out, []rune(render(string(run)))...)
+			run = run[:0]
+		}
+	}
+	for i, r := range []rune(text) {
+		if highlighted[i] {
+			run = append(run, r)
+			continue
+		}
+		flush()
+		out = append(out, r)
+	}
+	flush()
+	return string(out)
+}
@@ -0,0 +1,73 @@
+package views
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestFuzzyMatch checks which texts match a pattern and where
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		positions     []int
+		ok            bool
+	}{
+		{"", "anything", nil, true},
+		{"exp", "Export to CSV", []int{0, 1, 2}, true},
+		{"csv", "Export to CSV", []int{10, 11, 12}, true},
+		{"etc", "Export to CSV", []int{0, 5, 10}, true},
+		{"xe", "Export", nil, false},
+		{"exports", "Export", nil, false},
+		{"é", "Café crème", []int{3}, true},
+		{"ÉÈ", "café crème", []int{3, 7}, true},
+	}
+
+	for _, tt := range tests {
+		_, positions, ok := fuzzyMatch(tt.pattern, tt.text)
+		if ok != tt.ok || !slices.Equal(positions, tt.positions) {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, %v, want %v, %v", tt.pattern, tt.text, positions, ok, tt.positions, tt.ok)
+		}
+	}
+}
+
+// TestFuzzyMatchScore checks that consecutive matches and matches at the start of
+// words rank before scattered ones
+func TestFuzzyMatchScore(t *testing.T) {
+	tests := []struct {
+		pattern, better, worse string
+	}{
+		{"log", "Login page", "Slow gallery"},
+		{"log", "Fix log rotation", "Catalog import"},
+		{"ui", "UI polish", "Build pipeline"},
+	}
+
+	for _, tt := range tests {
+		better, _, _ := fuzzyMatch(tt.pattern, tt.better)
+		worse, _, _ := fuzzyMatch(tt.pattern, tt.worse)
+		if better <= worse {
+			t.Errorf("%q scores %d in %q and %d in %q", tt.pattern, better, tt.better, worse, tt.worse)
+		}
+	}
+}
+
+// TestHighlightRunes checks that runs of matched runes are rendered together
+func TestHighlightRunes(t *testing.T) {
+	brackets := func(s string) string { return "[" + s + "]" }
+	tests := []struct {
+		text      string
+		positions []int
+		want      string
+	}{
+		{"Export to CSV", nil, "Export to CSV"},
+		{"Export to CSV", []int{0, 1, 2}, "[Exp]ort to CSV"},
+		{"Export to CSV", []int{0, 7, 10, 11, 12}, "[E]xport [t]o [CSV]"},
+		{"Café crème", []int{3, 7}, "Caf[é] cr[è]me"},
+		{"short", []int{3, 4, 9}, "sho[rt]"},
+	}
+
+	for _, tt := range tests {
+		if got := highlightRunes(tt.text, tt.positions, brackets); got != tt.want {
+			t.Errorf("highlightRunes(%q, %v) = %q, want %q", tt.text, tt.positions, got, tt.want)
+		}
+	}
+}
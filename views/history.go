@@ -0,0 +1,430 @@
+package views
+
+import (
+	"fazure/azure"
+	"fazure/forms"
+	"fazure/markup"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// maxHistoryLines limits how many lines of the timeline are shown at once
+	maxHistoryLines = 18
+	// historyValueWidth is the width field values are shortened to in the timeline
+	historyValueWidth = 40
+)
+
+// historyLoadedMsg is sent when the updates of a work item have been fetched
+type historyLoadedMsg struct {
+	id      int
+	updates []azure.Update
+	err     error
+}
+
+// revisionsLoadedMsg is sent when the revisions of a work item have been fetched
+type revisionsLoadedMsg struct {
+	id        int
+	revisions []azure.WorkItem
+	err       error
+}
+
+// historyEntry is an update or a comment in the timeline of a work item
+type historyEntry struct {
+	date    time.Time
+	update  *azure.Update
+	comment *azure.Comment
+}
+
+// revisionDiff is the comparison of two revisions of a work item
+type revisionDiff struct {
+	from    int
+	to      int
+	changes azure.Update
+}
+
+// historyField implements forms.FormField for the history of a work item, a timeline
+// of its updates and comments, newest first. While editing, space marks revisions
+// and enter compares the two marked revisions, or a marked revision with the one
+// under the cursor, or the revision under the cursor with the one before it.
+// The updates are fetched when the field is first focused, and the revisions when
+// they are first compared.
+type historyField struct {
+	load      func() tea.Cmd
+	requested bool
+	updates   []azure.Update
+	comments  []azure.Comment
+	revisions []azure.WorkItem
+	loading   bool
+	err       error
+
+	focused bool
+	editing bool
+	cursor  int
+	marked  []int
+	diff    *revisionDiff
+	// compare is the comparison waiting for the revisions to be fetched
+	compare *revisionDiff
+}
+
+// newHistoryField creates the history of a work item, fetched by load when first needed
+func newHistoryField(load func() tea.Cmd) *historyField {
+	return &historyField{load: load}
+}
+
+// request fetches the updates unless they have been requested already
+func (h *historyField) request() tea.Cmd {
+	if h.requested {
+		return nil
+	}
+	h.requested = true
+	h.loading = true
+	return h.load()
+}
+
+// reload fetches the updates again after the work item changed, if they have been requested
+func (h *historyField) reload() tea.Cmd {
+	if !h.requested {
+		return nil
+	}
+	h.requested = false
+	h.revisions = nil
+	return h.request()
+}
+
+// setUpdates records the fetched updates
+func (h *historyField) setUpdates(updates []azure.Update, err error) {
+	h.loading = false
+	h.err = err
+	if err == nil {
+		h.updates = updates
+	}
+}
+
+// fetchRevisions reports whether a comparison waits for revisions that are not
+// being fetched yet, marking them as being fetched
+func (h *historyField) fetchRevisions() bool {
+	if h.compare == nil || h.revisions != nil || h.loading {
+		return false
+	}
+	h.loading = true
+	return true
+}
+
+// setRevisions records the fetched revisions and completes the waiting comparison
+func (h *historyField) setRevisions(revisions []azure.WorkItem, err error) {
+	h.loading = false
+	if err != nil {
+		h.err = err
+		h.compare = nil
+		return
+	}
+	h.revisions = revisions
+	if h.compare != nil {
+		h.showDiff(h.compare.from, h.compare.to)
+		h.compare = nil
+	}
+}
+
+// entries returns the updates and comments ordered from newest to oldest
+func (h *historyField) entries() []historyEntry {
+	var entries []historyEntry
+	for i := range h.updates {
+		entries = append(entries, historyEntry{date: h.updates[i].Date, update: &h.updates[i]})
+	}
+	for i := range h.comments {
+		date, _ := time.ParseInLocation("2006-01-02 15:04", h.comments[i].Date, time.Local)
+		entries = append(entries, historyEntry{date: date, comment: &h.comments[i]})
+	}
+	slices.SortStableFunc(entries, func(a, b historyEntry) int {
+		return b.date.Compare(a.date)
+	})
+	return entries
+}
+
+func (h *historyField) Update(form *forms.Form, msg tea.Msg) tea.Cmd {
+	if !h.editing {
+		return nil
+	}
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	entries := h.entries()
+	switch key.String() {
+	case "j", "down":
+		if h.cursor < len(entries)-1 {
+			h.cursor++
+		}
+	case "k", "up":
+		if h.cursor > 0 {
+			h.cursor--
+		}
+	case " ":
+		if h.cursor >= len(entries) || entries[h.cursor].update == nil {
+			return nil
+		}
+		rev := entries[h.cursor].update.Rev
+		if i := slices.Index(h.marked, rev); i >= 0 {
+			h.marked = slices.Delete(h.marked, i, i+1)
+		} else {
+			// Marking a third revision replaces the oldest mark
+			h.marked = append(h.marked, rev)[max(len(h.marked)-1, 0):]
+		}
+	}
+	return nil
+}
+
+func (h *historyField) View(form *forms.Form) string {
+	var s strings.Builder
+	s.WriteString("\n")
+
+	switch {
+	case h.err != nil:
+		s.WriteString(ErrorStyle.Render(wrapText(describeError(h.err), discussionWidth)))
+		s.WriteString("\n")
+	case h.loading && h.compare != nil:
+		s.WriteString(CommentDateStyle.Render("Loading revisions..."))
+		s.WriteString("\n")
+	case h.loading:
+		s.WriteString(CommentDateStyle.Render("Loading history..."))
+		s.WriteString("\n")
+	case h.diff != nil && !h.editing:
+		s.WriteString(h.renderDiff())
+	default:
+		s.WriteString(h.renderTimeline())
+	}
+
+	s.WriteString("\n")
+	switch {
+	case h.editing:
+		s.WriteString(HelpStyle.Render("'space' to mark revisions • 'enter' to compare the marked revisions or the one under the cursor • 'esc' to cancel"))
+	case h.focused && h.diff != nil:
+		s.WriteString(HelpStyle.Render("(Press enter to return to the history)"))
+	case h.focused:
+		s.WriteString(HelpStyle.Render("(Press enter to browse the history and compare revisions)"))
+	}
+	return s.String()
+}
+
+// renderTimeline renders the entries around the cursor
+func (h *historyField) renderTimeline() string {
+	entries := h.entries()
+	if len(entries) == 0 {
+		return CommentDateStyle.Render("No history yet.") + "\n"
+	}
+
+	var lines []string
+	cursorLine, cursorEnd := 0, 0
+	for i, entry := range entries {
+		if i == h.cursor {
+			cursorLine = len(lines)
+		}
+		lines = append(lines, h.renderEntry(entry, h.editing && i == h.cursor)...)
+		if i == h.cursor {
+			cursorEnd = len(lines)
+		}
+	}
+
+	// Scroll just far enough to show the whole entry under the cursor
+	offset := 0
+	if cursorEnd > maxHistoryLines {
+		offset = min(cursorLine, cursorEnd-maxHistoryLines)
+	}
+	end := min(offset+maxHistoryLines, len(lines))
+
+	var s strings.Builder
+	if offset > 0 {
+		s.WriteString(CommentDateStyle.Render(fmt.Sprintf("  ↑ %s", pluralize(offset, "more line", "more lines"))) + "\n")
+	}
+	for _, line := range lines[offset:end] {
+		s.WriteString(line + "\n")
+	}
+	if end < len(lines) {
+		s.WriteString(CommentDateStyle.Render(fmt.Sprintf("  ↓ %s", pluralize(len(lines)-end, "more line", "more lines"))) + "\n")
+	}
+	return s.String()
+}
+
+// renderEntry renders an update with its changes, or a comment with its text
+func (h *historyField) renderEntry(entry historyEntry, selected bool) []string {
+	cursor := "  "
+	if selected {
+		cursor = "▶ "
+	}
+
+	if entry.comment != nil {
+		c := entry.comment
+		lines := []string{cursor + "✎ " + CommentAuthorStyle.Render(c.Author) + " commented " + CommentDateStyle.Render(c.Date)}
+		for _, line := range strings.Split(wrapText(markup.ToPlain(c.Content), discussionWidth), "\n") {
+			lines = append(lines, "      "+line)
+		}
+		return lines
+	}
+
+	u := entry.update
+	mark := "○ "
+	if slices.Contains(h.marked, u.Rev) {
+		mark = ActiveOptionStyle.Render("◉ ")
+	}
+	lines := []string{cursor + mark + TitleDetailStyle.Render(fmt.Sprintf("Rev %d", u.Rev)) + " • " +
+		CommentAuthorStyle.Render(u.RevisedBy) + " • " + CommentDateStyle.Render(u.Date.Local().Format("2006-01-02 15:04"))}
+	for _, line := range describeUpdate(*u) {
+		lines = append(lines, "      "+line)
+	}
+	return lines
+}
+
+// describeUpdate lists the field and link changes of an update, one per line
+func describeUpdate(u azure.Update) []string {
+	var lines []string
+	for _, change := range u.Fields {
+		lines = append(lines, fmt.Sprintf("%s %s → %s",
+			FieldLabelStyle.UnsetWidth().Render(fieldLabel(change.Field)+":"),
+			CommentDateStyle.Render(historyValue(fieldText(change.Field, change.OldValue))),
+			historyValue(fieldText(change.Field, change.NewValue))))
+	}
+	for _, rel := range u.AddedRelations {
+		lines = append(lines, SuccessStyle.Render("+ ")+linkLabel(rel.Rel)+" "+linkTarget(rel))
+	}
+	for _, rel := range u.RemovedRelations {
+		lines = append(lines, ErrorStyle.Render("- ")+linkLabel(rel.Rel)+" "+linkTarget(rel))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, CommentDateStyle.Render("No visible changes"))
+	}
+	return lines
+}
+
+// historyValue shortens a field value to a single line of the timeline
+func historyValue(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return "(empty)"
+	}
+	return truncate(value, historyValueWidth)
+}
+
+// renderDiff renders the comparison of two revisions
+func (h *historyField) renderDiff() string {
+	var s strings.Builder
+	s.WriteString(TitleDetailStyle.Render(fmt.Sprintf("Changes from rev %d to rev %d", h.diff.from, h.diff.to)))
+	s.WriteString("\n")
+	lines := describeUpdate(h.diff.changes)
+	if len(lines) > maxHistoryLines {
+		lines = append(lines[:maxHistoryLines], CommentDateStyle.Render(fmt.Sprintf("... and %d more", len(lines)-maxHistoryLines)))
+	}
+	for _, line := range lines {
+		s.WriteString("  " + line + "\n")
+	}
+	return s.String()
+}
+
+func (h *historyField) Label() string {
+	return ""
+}
+
+func (h *historyField) Terminator() string {
+	return "enter"
+}
+
+func (h *historyField) Focus() tea.Cmd {
+	h.focused = true
+	return h.request()
+}
+
+func (h *historyField) Blur() {
+	h.focused = false
+}
+
+func (h *historyField) Edit() tea.Cmd {
+	h.editing = true
+	h.diff = nil
+	h.err = nil
+	h.cursor = min(h.cursor, max(len(h.entries())-1, 0))
+	return h.request()
+}
+
+// Save compares the chosen revisions
+func (h *historyField) Save() {
+	h.editing = false
+
+	entries := h.entries()
+	current := 0
+	if h.cursor < len(entries) && entries[h.cursor].update != nil {
+		current = entries[h.cursor].update.Rev
+	}
+	var from, to int
+	switch {
+	case len(h.marked) == 2:
+		from, to = h.marked[0], h.marked[1]
+	case len(h.marked) == 1 && current != 0 && current != h.marked[0]:
+		from, to = h.marked[0], current
+	case len(h.marked) == 0 && current != 0:
+		from, to = current-1, current
+	default:
+		return
+	}
+	from, to = min(from, to), max(from, to)
+	h.marked = nil
+	if h.revisions == nil {
+		h.compare = &revisionDiff{from: from, to: to}
+		return
+	}
+	h.showDiff(from, to)
+}
+
+// showDiff compares two of the fetched revisions
+func (h *historyField) showDiff(from, to int) {
+	// Revision 0 is the work item before it was created
+	var before azure.WorkItem
+	if from > 0 {
+		rev, ok := h.revision(from)
+		if !ok {
+			h.err = fmt.Errorf("revision %d is not available", from)
+			return
+		}
+		before = rev
+	}
+	after, ok := h.revision(to)
+	if !ok {
+		h.err = fmt.Errorf("revision %d is not available", to)
+		return
+	}
+	h.diff = &revisionDiff{from: from, to: to, changes: azure.DiffRevisions(before, after)}
+}
+
+// revision returns the revision with the given number
+func (h *historyField) revision(rev int) (azure.WorkItem, bool) {
+	i := slices.IndexFunc(h.revisions, func(r azure.WorkItem) bool { return r.Rev == rev })
+	if i < 0 {
+		return azure.WorkItem{}, false
+	}
+	return h.revisions[i], true
+}
+
+func (h *historyField) Cancel() {
+	h.editing = false
+	h.marked = nil
+}
+
+// loadHistory fetches the updates of a work item
+func loadHistory(m Model, id int) tea.Cmd {
+	return func() tea.Msg {
+		updates, err := m.azure.GetUpdates(id)
+		return historyLoadedMsg{id: id, updates: updates, err: err}
+	}
+}
+
+// loadRevisions fetches the revisions of a work item
+func loadRevisions(m Model, id int) tea.Cmd {
+	return func() tea.Msg {
+		revisions, err := m.azure.GetRevisions([]int{id})
+		return revisionsLoadedMsg{id: id, revisions: revisions[id], err: err}
+	}
+}
@@ -0,0 +1,470 @@
+package views
+
+import (
+	"fazure/azure"
+	"fazure/forms"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// linkTargetsLoadedMsg is sent when the work items linked from a work item have been fetched
+type linkTargetsLoadedMsg struct {
+	id    int
+	items []azure.WorkItem
+	err   error
+}
+
+// openLinkMsg is sent to open the details of a linked work item
+type openLinkMsg struct {
+	item azure.WorkItem
+}
+
+// linkKind is a link type with a display label
+type linkKind struct {
+	label string
+	rel   string
+}
+
+// linkKinds are the kinds of links that can be added from the Links tab
+var linkKinds = []linkKind{
+	{"Parent", azure.RelParent},
+	{"Child", azure.RelChild},
+	{"Related", azure.RelRelated},
+	{"Predecessor", azure.RelPredecessor},
+	{"Successor", azure.RelSuccessor},
+	{"Hyperlink", azure.RelHyperlink},
+}
+
+// linkGroups are the headings links to work items are listed under, in display order.
+// Other links to work items are listed under Other, links to anything else under External.
+var linkGroups = []linkKind{
+	{"Parent", azure.RelParent},
+	{"Children", azure.RelChild},
+	{"Related", azure.RelRelated},
+	{"Predecessors", azure.RelPredecessor},
+	{"Successors", azure.RelSuccessor},
+}
+
+// pendingLink is a link to add with the next save
+type pendingLink struct {
+	rel    string
+	target string
+}
+
+// linksField implements forms.FormField for the links of a work item. While editing,
+// enter opens the selected linked work item, or removes the links marked with 'd',
+// or adds the link typed after 'a'.
+type linksField struct {
+	relations []azure.Relation
+	targets   map[int]azure.WorkItem
+	loading   bool
+	err       error
+
+	focused bool
+	editing bool
+	cursor  int
+	marked  map[int]bool
+	adding  bool
+	kind    int
+	input   textinput.Model
+
+	open    *azure.WorkItem
+	add     *pendingLink
+	removed []int
+}
+
+func newLinksField(relations []azure.Relation) *linksField {
+	input := textinput.New()
+	input.Placeholder = "Work item ID"
+	input.Width = 40
+	return &linksField{
+		relations: relations,
+		targets:   map[int]azure.WorkItem{},
+		loading:   true,
+		marked:    map[int]bool{},
+		kind:      slices.IndexFunc(linkKinds, func(k linkKind) bool { return k.rel == azure.RelRelated }),
+		input:     input,
+	}
+}
+
+// order returns the indices of the relations in display order
+func (l *linksField) order() []int {
+	var order []int
+	for _, g := range linkGroups {
+		for i, rel := range l.relations {
+			if rel.Rel == g.rel {
+				order = append(order, i)
+			}
+		}
+	}
+	for _, external := range []bool{false, true} {
+		for i, rel := range l.relations {
+			if !isGroupedLink(rel) && (rel.TargetID == 0) == external {
+				order = append(order, i)
+			}
+		}
+	}
+	return order
+}
+
+// isGroupedLink reports whether a relation is listed under one of the linkGroups
+func isGroupedLink(rel azure.Relation) bool {
+	return slices.ContainsFunc(linkGroups, func(g linkKind) bool { return g.rel == rel.Rel })
+}
+
+// groupTitle returns the heading a relation is listed under
+func groupTitle(rel azure.Relation) string {
+	for _, g := range linkGroups {
+		if g.rel == rel.Rel {
+			return g.label
+		}
+	}
+	if rel.TargetID == 0 {
+		return "External"
+	}
+	return "Other"
+}
+
+// takeOpen returns the linked work item chosen to be opened, if any
+func (l *linksField) takeOpen() *azure.WorkItem {
+	item := l.open
+	l.open = nil
+	return item
+}
+
+// takeChanges returns the links to remove, in descending index order, and the link to add
+func (l *linksField) takeChanges() ([]int, *pendingLink) {
+	removed, add := l.removed, l.add
+	l.removed, l.add = nil, nil
+	return removed, add
+}
+
+func (l *linksField) Update(form *forms.Form, msg tea.Msg) tea.Cmd {
+	if !l.editing {
+		return nil
+	}
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	if l.adding {
+		switch key.String() {
+		case "tab":
+			l.kind = (l.kind + 1) % len(linkKinds)
+			l.updatePlaceholder()
+			return nil
+		case "shift+tab":
+			l.kind = (l.kind - 1 + len(linkKinds)) % len(linkKinds)
+			l.updatePlaceholder()
+			return nil
+		}
+		var cmd tea.Cmd
+		l.input, cmd = l.input.Update(key)
+		return cmd
+	}
+
+	order := l.order()
+	switch key.String() {
+	case "j", "down":
+		if l.cursor < len(order)-1 {
+			l.cursor++
+		}
+	case "k", "up":
+		if l.cursor > 0 {
+			l.cursor--
+		}
+	case "d", "x":
+		if l.cursor < len(order) {
+			i := order[l.cursor]
+			if l.marked[i] {
+				delete(l.marked, i)
+			} else {
+				l.marked[i] = true
+			}
+		}
+	case "a":
+		l.adding = true
+		l.err = nil
+		l.updatePlaceholder()
+		return l.input.Focus()
+	}
+	return nil
+}
+
+// updatePlaceholder describes the target expected by the selected link kind
+func (l *linksField) updatePlaceholder() {
+	if linkKinds[l.kind].rel == azure.RelHyperlink {
+		l.input.Placeholder = "URL"
+	} else {
+		l.input.Placeholder = "Work item ID"
+	}
+}
+
+func (l *linksField) View(form *forms.Form) string {
+	var s strings.Builder
+	s.WriteString("\n")
+
+	if len(l.relations) == 0 {
+		s.WriteString(CommentDateStyle.Render("No links yet."))
+		s.WriteString("\n")
+	}
+
+	group := ""
+	for pos, i := range l.order() {
+		rel := l.relations[i]
+		if title := groupTitle(rel); title != group {
+			group = title
+			s.WriteString(FieldLabelStyle.Render(title))
+			s.WriteString("\n")
+		}
+
+		cursor := "  "
+		if l.editing && !l.adding && pos == l.cursor {
+			cursor = "▶ "
+		}
+		line := l.describe(rel)
+		if l.marked[i] {
+			line = ErrorStyle.Render("✗ ") + line + ErrorStyle.Render(" (remove)")
+		}
+		s.WriteString(cursor + line + "\n")
+	}
+
+	if l.loading && slices.ContainsFunc(l.relations, func(r azure.Relation) bool { return r.TargetID != 0 }) {
+		s.WriteString(CommentDateStyle.Render("Loading linked work items..."))
+		s.WriteString("\n")
+	}
+	if l.err != nil {
+		s.WriteString(ErrorStyle.Render(wrapText(describeError(l.err), discussionWidth)))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	switch {
+	case l.adding:
+		s.WriteString(FieldLabelStyle.Render("New link: "))
+		s.WriteString(ActiveOptionStyle.Render("◀ " + linkKinds[l.kind].label + " ▶"))
+		s.WriteString(" " + l.input.View())
+		s.WriteString("\n")
+		s.WriteString(HelpStyle.Render("'tab' to change the link type • 'enter' to add • 'esc' to cancel"))
+	case l.editing:
+		s.WriteString(HelpStyle.Render("'enter' to open or remove marked • 'd' to mark for removal • 'a' to add a link • 'esc' to cancel"))
+	case l.focused:
+		s.WriteString(HelpStyle.Render("(Press enter to browse links)"))
+	}
+	return s.String()
+}
+
+// describe renders a link with badges for the type and state of linked work items
+func (l *linksField) describe(rel azure.Relation) string {
+	if rel.TargetID == 0 {
+		name := rel.Name
+		if name == "" {
+			name = rel.Rel
+		}
+		line := FieldValueStyle.Render(name) + "  " + rel.URL
+		if rel.Comment != "" {
+			line += CommentDateStyle.Render("  " + rel.Comment)
+		}
+		return line
+	}
+
+	target, ok := l.targets[rel.TargetID]
+	if !ok {
+		return fmt.Sprintf("#%d", rel.TargetID)
+	}
+	badge := GetWorkItemTypeStyle(target.Type).Render(fmt.Sprintf("%s #%d", target.Type, target.ID))
+	state := CommentDateStyle.Render("[" + target.State + "]")
+	line := badge + " " + truncate(target.Title, discussionWidth) + " " + state
+	if groupTitle(rel) == "Other" {
+		line = CommentDateStyle.Render(rel.Rel+" ") + line
+	}
+	return line
+}
+
+func (l *linksField) Label() string {
+	return ""
+}
+
+func (l *linksField) Terminator() string {
+	return "enter"
+}
+
+func (l *linksField) Focus() tea.Cmd {
+	l.focused = true
+	return nil
+}
+
+func (l *linksField) Blur() {
+	l.focused = false
+}
+
+func (l *linksField) Edit() tea.Cmd {
+	l.editing = true
+	l.cursor = min(l.cursor, max(len(l.relations)-1, 0))
+	return nil
+}
+
+// Save records the action chosen while editing, to be carried out by the details view
+func (l *linksField) Save() {
+	l.editing = false
+
+	switch {
+	case l.adding:
+		if target := strings.TrimSpace(l.input.Value()); target != "" {
+			l.add = &pendingLink{rel: linkKinds[l.kind].rel, target: target}
+		}
+		l.stopAdding()
+	case len(l.marked) > 0:
+		l.removed = slices.Sorted(maps.Keys(l.marked))
+		slices.Reverse(l.removed)
+		l.marked = map[int]bool{}
+	default:
+		order := l.order()
+		if l.cursor < len(order) {
+			rel := l.relations[order[l.cursor]]
+			if target, ok := l.targets[rel.TargetID]; ok {
+				l.open = &target
+			} else if rel.TargetID != 0 {
+				l.open = &azure.WorkItem{ID: rel.TargetID}
+			}
+		}
+	}
+}
+
+func (l *linksField) Cancel() {
+	l.editing = false
+	l.marked = map[int]bool{}
+	l.stopAdding()
+}
+
+func (l *linksField) stopAdding() {
+	l.adding = false
+	l.input.Blur()
+	l.input.Reset()
+}
+
+// setRelations replaces the listed links after the work item has been saved
+func (l *linksField) setRelations(relations []azure.Relation) {
+	l.relations = relations
+	l.marked = map[int]bool{}
+	l.cursor = min(l.cursor, max(len(relations)-1, 0))
+}
+
+// loadLinkTargets fetches the type, title and state of the work items a work item links to
+func loadLinkTargets(m Model, id int, relations []azure.Relation) tea.Cmd {
+	var ids []int
+	for _, rel := range relations {
+		if rel.TargetID != 0 && !slices.Contains(ids, rel.TargetID) {
+			ids = append(ids, rel.TargetID)
+		}
+	}
+	return func() tea.Msg {
+		if len(ids) == 0 {
+			return linkTargetsLoadedMsg{id: id}
+		}
+		items, err := m.azure.GetWorkItems(ids)
+		return linkTargetsLoadedMsg{id: id, items: items, err: err}
+	}
+}
+
+// linkChanges turns the links added and removed in the Links tab into patch operations
+func linkChanges(m Model, links *linksField) ([]azure.PatchOperation, error) {
+	removed, add := links.takeChanges()
+
+	var ops []azure.PatchOperation
+	for _, i := range removed {
+		ops = append(ops, azure.RemoveRelation(i))
+	}
+	if add == nil {
+		return ops, nil
+	}
+
+	url := add.target
+	if add.rel != azure.RelHyperlink {
+		id, err := strconv.Atoi(strings.TrimPrefix(add.target, "#"))
+		if err != nil || id <= 0 {
+			return ops, fmt.Errorf("%q is not a work item ID", add.target)
+		}
+		url = m.azure.WorkItemURL(id)
+	}
+	return append(ops, azure.AddRelation(add.rel, url, "")), nil
+}
+
+// removedRelation returns the index of the relation a remove operation deletes
+func removedRelation(op azure.PatchOperation) (int, bool) {
+	index, ok := strings.CutPrefix(op.Path, "/relations/")
+	if !ok || op.Op != "remove" {
+		return 0, false
+	}
+	i, err := strconv.Atoi(index)
+	return i, err == nil
+}
+
+// rebaseLinkOps points the operations that remove relations in from at the same links
+// in to, dropping those whose link no longer exists. The removals come first, from the
+// highest index down, so that each index still addresses its link when it is applied.
+func rebaseLinkOps(ops []azure.PatchOperation, from, to []azure.Relation) []azure.PatchOperation {
+	var removals []int
+	var others []azure.PatchOperation
+	for _, op := range ops {
+		i, ok := removedRelation(op)
+		if !ok {
+			others = append(others, op)
+			continue
+		}
+		if i >= len(from) {
+			continue
+		}
+		j := slices.IndexFunc(to, func(r azure.Relation) bool {
+			return r.Rel == from[i].Rel && r.URL == from[i].URL
+		})
+		if j >= 0 && !slices.Contains(removals, j) {
+			removals = append(removals, j)
+		}
+	}
+
+	slices.Sort(removals)
+	slices.Reverse(removals)
+	rebased := make([]azure.PatchOperation, 0, len(removals)+len(others))
+	for _, j := range removals {
+		rebased = append(rebased, azure.RemoveRelation(j))
+	}
+	return append(rebased, others...)
+}
+
+// describeLinkOp describes an operation that adds or removes a link
+func describeLinkOp(op azure.PatchOperation, relations []azure.Relation) string {
+	if i, ok := removedRelation(op); ok && i < len(relations) {
+		return fmt.Sprintf("Remove %s %s", linkLabel(relations[i].Rel), linkTarget(relations[i]))
+	}
+	if value, ok := op.Value.(azure.RelationValue); ok {
+		rel := azure.Relation{Rel: value.Rel, URL: value.URL, TargetID: azure.LinkTargetID(value.URL)}
+		return fmt.Sprintf("Add %s %s", linkLabel(rel.Rel), linkTarget(rel))
+	}
+	return fmt.Sprint(op.Value)
+}
+
+// linkLabel returns the display label of a link type
+func linkLabel(rel string) string {
+	for _, k := range linkKinds {
+		if k.rel == rel {
+			return k.label
+		}
+	}
+	return rel
+}
+
+// linkTarget names the target of a link
+func linkTarget(rel azure.Relation) string {
+	if rel.TargetID != 0 {
+		return fmt.Sprintf("#%d", rel.TargetID)
+	}
+	return rel.URL
+}
@@ -0,0 +1,69 @@
+package views
+
+import (
+	"fazure/azure"
+	"reflect"
+	"testing"
+)
+
+// TestRebaseLinkOps checks that removed links are found in the latest revision and
+// removed from the highest index down, before any other operation
+func TestRebaseLinkOps(t *testing.T) {
+	parent := azure.Relation{Rel: azure.RelParent, URL: "https://example.com/_apis/wit/workItems/1"}
+	child := azure.Relation{Rel: azure.RelChild, URL: "https://example.com/_apis/wit/workItems/2"}
+	related := azure.Relation{Rel: azure.RelRelated, URL: "https://example.com/_apis/wit/workItems/3"}
+	added := azure.Relation{Rel: azure.RelRelated, URL: "https://example.com/_apis/wit/workItems/4"}
+	setTitle := azure.SetField(azure.FieldTitle, "Export to CSV")
+	addLink := azure.AddRelation(added.Rel, added.URL, "")
+
+	tests := []struct {
+		name string
+		ops  []azure.PatchOperation
+		from []azure.Relation
+		to   []azure.Relation
+		want []azure.PatchOperation
+	}{
+		{
+			name: "unchanged relations",
+			ops:  []azure.PatchOperation{azure.RemoveRelation(0), azure.RemoveRelation(2)},
+			from: []azure.Relation{parent, child, related},
+			to:   []azure.Relation{parent, child, related},
+			want: []azure.PatchOperation{azure.RemoveRelation(2), azure.RemoveRelation(0)},
+		},
+		{
+			name: "reordered relations",
+			ops:  []azure.PatchOperation{azure.RemoveRelation(0), azure.RemoveRelation(1)},
+			from: []azure.Relation{parent, child, related},
+			to:   []azure.Relation{child, related, parent},
+			want: []azure.PatchOperation{azure.RemoveRelation(2), azure.RemoveRelation(0)},
+		},
+		{
+			name: "link removed in the meantime",
+			ops:  []azure.PatchOperation{azure.RemoveRelation(1), azure.RemoveRelation(2)},
+			from: []azure.Relation{parent, child, related},
+			to:   []azure.Relation{parent, related},
+			want: []azure.PatchOperation{azure.RemoveRelation(1)},
+		},
+		{
+			name: "other operations last",
+			ops:  []azure.PatchOperation{setTitle, azure.RemoveRelation(0), addLink, azure.RemoveRelation(1)},
+			from: []azure.Relation{parent, child},
+			to:   []azure.Relation{related, parent, child},
+			want: []azure.PatchOperation{azure.RemoveRelation(2), azure.RemoveRelation(1), setTitle, addLink},
+		},
+		{
+			name: "index out of range",
+			ops:  []azure.PatchOperation{azure.RemoveRelation(5), setTitle},
+			from: []azure.Relation{parent},
+			to:   []azure.Relation{parent},
+			want: []azure.PatchOperation{setTitle},
+		},
+	}
+
+	for _, tt := range tests {
+		got := rebaseLinkOps(tt.ops, tt.from, tt.to)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -38,6 +38,7 @@ func (v *LoginView) Update(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case "enter":
 			m.user = v.userInput.Value()
+			m.query = defaultQuery(m.user)
 			m.view = &BacklogView{}
 			return m, m.view.Init(m)
 		}
@@ -2,6 +2,7 @@ package views
 
 import (
 	"fazure/azure"
+	"fazure/config"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -10,19 +11,42 @@ import (
 type Model struct {
 	view           View
 	user           string
-	azure          *azure.AzureClient
+	query          azure.QueryParams
+	sort           []columnSort
+	tree           bool
+	config         config.Config
+	azure          azure.WorkItemService
 	terminalWidth  int
 	terminalHeight int
 }
 
-func NewModel() Model {
-	return Model{
-		user:  os.Getenv("AZURE_USER"),
-		view:  &BacklogView{},
-		azure: azure.NewClient(
-			os.Getenv("AZURE_ORG"), 
-			os.Getenv("AZURE_PROJECT"), 
-			os.Getenv("AZURE_PAT")),
+// NewModel creates the application model backed by the given work item service and settings.
+// It opens the backlog of AZURE_USER, or asks for a user when it is not set.
+func NewModel(service azure.WorkItemService, cfg config.Config) Model {
+	user := os.Getenv("AZURE_USER")
+	m := Model{
+		user:   user,
+		query:  defaultQuery(user),
+		config: cfg,
+		view:   &BacklogView{},
+		azure:  service,
+	}
+	if m.user == "" {
+		m.view = &LoginView{}
+	}
+	return m
+}
+
+// defaultQuery returns the backlog query for a user: every unfinished work item
+// assigned to them, most important first
+func defaultQuery(user string) azure.QueryParams {
+	return azure.QueryParams{
+		AssignedTo:    user,
+		ExcludeStates: azure.TerminalStates,
+		OrderBy: []azure.SortKey{
+			{Field: azure.SortByPriority},
+			{Field: azure.SortByChangedDate, Descending: true},
+		},
 	}
 }
 
@@ -35,7 +59,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.terminalWidth = msg.Width
 		m.terminalHeight = msg.Height
-		return m, nil
+		// Views which lay themselves out when they start are told about the new size
+		return m.view.Update(m, msg)
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c":
@@ -0,0 +1,97 @@
+package views
+
+import (
+	"fazure/azure"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// searchBar is the incremental search input of the backlog
+type searchBar struct {
+	input  textinput.Model
+	active bool
+}
+
+func newSearchBar() *searchBar {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "ID, title, tag or assignee"
+	input.Width = 40
+	return &searchBar{input: input}
+}
+
+// Value returns the search text
+func (b *searchBar) Value() string {
+	return strings.TrimSpace(b.input.Value())
+}
+
+// Focus starts typing a search
+func (b *searchBar) Focus() tea.Cmd {
+	b.active = true
+	return b.input.Focus()
+}
+
+// Blur stops typing, keeping the search text
+func (b *searchBar) Blur() {
+	b.active = false
+	b.input.Blur()
+}
+
+// Reset clears the search
+func (b *searchBar) Reset() {
+	b.Blur()
+	b.input.Reset()
+}
+
+func (b *searchBar) View(matches, total int) string {
+	count := CommentDateStyle.Render(fmt.Sprintf("  %d of %d", matches, total))
+	if b.active {
+		return b.input.View() + count
+	}
+	return CommentDateStyle.Render("/"+b.input.Value()) + count
+}
+
+// updateSearch handles input while the search bar is focused
+func (v *BacklogView) updateSearch(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.search.Reset()
+		v.refreshRows()
+		return m, nil
+	case "enter":
+		v.search.Blur()
+		return m, nil
+	case "up", "down":
+		return m, v.table.Update(msg)
+	}
+
+	var cmd tea.Cmd
+	v.search.input, cmd = v.search.input.Update(msg)
+	v.refreshRows()
+	return m, cmd
+}
+
+// matchWorkItem fuzzy matches query against the ID, title, tags and assignee of a
+// work item. It returns the matched rune positions in the best matching field.
+func matchWorkItem(query string, item azure.WorkItem) (map[string][]int, bool) {
+	if query == "" {
+		return nil, true
+	}
+
+	best, bestField := 0, ""
+	var bestPositions []int
+	for _, field := range []string{azure.FieldID, azure.FieldTitle, azure.FieldAssignedTo, azure.FieldTags} {
+		score, positions, ok := fuzzyMatch(query, item.Field(field))
+		if ok && (bestField == "" || score > best) {
+			best, bestField, bestPositions = score, field, positions
+		}
+	}
+
+	if bestField == "" {
+		return nil, false
+	}
+	return map[string][]int{bestField: bestPositions}, true
+}
@@ -0,0 +1,93 @@
+package views
+
+import (
+	"cmp"
+	"fazure/azure"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// columnSort sorts the backlog table by the field shown in one of its columns
+type columnSort struct {
+	field string
+	desc  bool
+}
+
+// maxSortKeys is the number of columns the backlog can be sorted by at once
+const maxSortKeys = 2
+
+// cycleSort cycles the sort order of a field through ascending, descending and
+// unsorted. The primary key is the first of keys, the secondary key the second.
+func cycleSort(keys []columnSort, field string, secondary bool) []columnSort {
+	keys = slices.Clone(keys)
+	pos := 0
+	if secondary && len(keys) > 0 && keys[0].field != field {
+		pos = 1
+	}
+
+	if pos < len(keys) && keys[pos].field == field {
+		if !keys[pos].desc {
+			keys[pos].desc = true
+			return keys
+		}
+		return slices.Delete(keys, pos, pos+1)
+	}
+
+	keys = slices.DeleteFunc(keys, func(k columnSort) bool { return k.field == field })
+	key := columnSort{field: field}
+	if pos < len(keys) {
+		keys[pos] = key
+	} else {
+		keys = append(keys, key)
+	}
+	return keys[:min(len(keys), maxSortKeys)]
+}
+
+// sortIndicator returns the header suffix of a column: filled arrows for the
+// primary sort key and hollow arrows for the secondary one
+func sortIndicator(keys []columnSort, field string) string {
+	for i, k := range keys {
+		if k.field != field {
+			continue
+		}
+		switch {
+		case i == 0 && !k.desc:
+			return " ▲"
+		case i == 0:
+			return " ▼"
+		case !k.desc:
+			return " △"
+		default:
+			return " ▽"
+		}
+	}
+	return ""
+}
+
+// compareField compares two work items by a field, numerically when both values are numbers
+func compareField(a, b azure.WorkItem, field string) int {
+	x, y := a.Field(field), b.Field(field)
+	if fx, err := strconv.ParseFloat(x, 64); err == nil {
+		if fy, err := strconv.ParseFloat(y, 64); err == nil {
+			return cmp.Compare(fx, fy)
+		}
+	}
+	return cmp.Compare(strings.ToLower(x), strings.ToLower(y))
+}
+
+// sortItems sorts work items by keys, keeping the query order for equal items
+func sortItems(items []azure.WorkItem, indices []int, keys []columnSort) {
+	slices.SortStableFunc(indices, func(i, j int) int {
+		for _, k := range keys {
+			c := compareField(items[i], items[j], k.field)
+			if k.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
@@ -0,0 +1,354 @@
+package views
+
+import (
+	"fazure/azure"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sprintFields are the fields fetched for the work items of the sprint summary
+var sprintFields = []string{
+	azure.FieldTitle,
+	azure.FieldAssignedTo,
+	azure.FieldState,
+	azure.FieldStoryPoints,
+	azure.FieldOriginalEstimate,
+	azure.FieldRemainingWork,
+	azure.FieldCompletedWork,
+}
+
+const (
+	// burndownHeight is the number of lines of the burndown chart
+	burndownHeight = 10
+	// capacityBarWidth is the width of the bars comparing assigned work to capacity
+	capacityBarWidth = 12
+)
+
+// sprintLoadedMsg is sent when the current iteration, its work items, the team's
+// capacity and the revisions of the work items have been fetched
+type sprintLoadedMsg struct {
+	iteration *azure.ClassificationNode
+	items     []azure.WorkItem
+	capacity  *azure.Capacity
+	burndown  []azure.BurndownDay
+	err       error
+}
+
+// SprintView summarizes the team's current iteration: the remaining work of each
+// team member against their capacity, and a burndown of the remaining work
+type SprintView struct {
+	iteration *azure.ClassificationNode
+	items     []azure.WorkItem
+	capacity  *azure.Capacity
+	burndown  []azure.BurndownDay
+	loading   bool
+	err       error
+}
+
+func (v *SprintView) Init(m Model) tea.Cmd {
+	return v.load(m)
+}
+
+// load fetches the current iteration, its work items and capacity, and computes
+// the burndown from the revisions of the work items
+func (v *SprintView) load(m Model) tea.Cmd {
+	v.loading = true
+	v.err = nil
+	return func() tea.Msg {
+		iteration, err := m.azure.GetCurrentIteration()
+		if err != nil {
+			return sprintLoadedMsg{err: err}
+		}
+		items, err := m.azure.QueryWorkItems(azure.QueryParams{
+			IterationPath: iteration.Path,
+			OrderBy:       []azure.SortKey{{Field: azure.SortByID}},
+			Fields:        sprintFields,
+		})
+		if err != nil {
+			return sprintLoadedMsg{err: err}
+		}
+		capacity, err := m.azure.GetCapacity(iteration)
+		if err != nil {
+			return sprintLoadedMsg{err: err}
+		}
+
+		ids := make([]int, len(items))
+		for i, item := range items {
+			ids[i] = item.ID
+		}
+		revisions, err := m.azure.GetRevisions(ids)
+		if err != nil {
+			return sprintLoadedMsg{err: err}
+		}
+		return sprintLoadedMsg{
+			iteration: iteration,
+			items:     items,
+			capacity:  capacity,
+			burndown:  azure.Burndown(capacity, revisions, time.Now()),
+		}
+	}
+}
+
+func (v *SprintView) View(m Model) string {
+	var s strings.Builder
+	s.WriteString(TitleStyle.Render("Sprint"))
+	s.WriteString("\n")
+	if v.iteration != nil {
+		s.WriteString(CommentDateStyle.Render(iterationSummary(v.iteration) + v.dayOfSprint()))
+	}
+	s.WriteString("\n\n")
+
+	switch {
+	case v.err != nil:
+		s.WriteString(errorBanner(v.err, getContentWidth(m.terminalWidth), true))
+		s.WriteString("\n\n")
+		s.WriteString(HelpStyle.Render("Press 'esc' to go back"))
+		return s.String()
+	case v.capacity == nil:
+		s.WriteString("Loading the current iteration...\n\n")
+		s.WriteString(HelpStyle.Render("Press 'esc' to go back"))
+		return s.String()
+	}
+
+	s.WriteString(v.renderTotals())
+	s.WriteString("\n\n")
+	capacity := v.renderCapacity()
+	burndown := v.renderBurndown()
+	if lipgloss.Width(capacity)+lipgloss.Width(burndown)+4 <= m.terminalWidth {
+		s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, capacity, "    ", burndown))
+	} else {
+		s.WriteString(capacity + "\n\n" + burndown)
+	}
+	s.WriteString("\n\n")
+	s.WriteString(HelpStyle.Render("Press 'r' to refresh • 'esc' for the backlog"))
+	return s.String()
+}
+
+// dayOfSprint tells which working day of the sprint today is, if it is one
+func (v *SprintView) dayOfSprint() string {
+	if v.capacity == nil {
+		return ""
+	}
+	days := v.capacity.Days()
+	today := time.Now()
+	for i, day := range days {
+		if day.Format(time.DateOnly) == today.Format(time.DateOnly) {
+			return fmt.Sprintf(" • day %d of %d", i+1, len(days))
+		}
+	}
+	return fmt.Sprintf(" • %s", pluralize(len(days), "working day", "working days"))
+}
+
+// formatHours formats an amount of work
+func formatHours(hours float64) string {
+	return strconv.FormatFloat(hours, 'f', -1, 64) + "h"
+}
+
+// renderTotals sums up the work and story points of the sprint
+func (v *SprintView) renderTotals() string {
+	var remaining, completed, points, donePoints float64
+	for _, item := range v.items {
+		if item.State == "Removed" {
+			continue
+		}
+		remaining += remainingWork(item)
+		completed += item.CompletedWork
+		points += item.StoryPoints
+		if slices.Contains(azure.TerminalStates, item.State) {
+			donePoints += item.StoryPoints
+		}
+	}
+	return fmt.Sprintf("%s • %s remaining • %s completed • %s story points, %s done",
+		pluralize(len(v.items), "work item", "work items"),
+		formatHours(remaining), formatHours(completed),
+		strconv.FormatFloat(points, 'f', -1, 64), strconv.FormatFloat(donePoints, 'f', -1, 64))
+}
+
+// remainingWork returns the remaining work of a work item, which is none once it is finished
+func remainingWork(item azure.WorkItem) float64 {
+	if slices.Contains(azure.TerminalStates, item.State) {
+		return 0
+	}
+	return item.RemainingWork
+}
+
+// capacityRow is a line of the capacity table
+type capacityRow struct {
+	name      string
+	perDay    float64
+	daysOff   int
+	available float64
+	assigned  float64
+}
+
+// capacityRows compares the capacity of each team member from today on with the
+// remaining work assigned to them, followed by people with work but no capacity
+// and the unassigned work
+func (v *SprintView) capacityRows() []capacityRow {
+	today := time.Now()
+	var rows []capacityRow
+	for _, member := range v.capacity.Members {
+		row := capacityRow{
+			name:      member.Member.DisplayName,
+			perDay:    member.PerDay(),
+			available: v.capacity.Available(member, today),
+		}
+		for _, day := range v.capacity.Days() {
+			if member.IsOff(day) {
+				row.daysOff++
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	unassigned := capacityRow{name: unassigned}
+	for _, item := range v.items {
+		if item.AssignedTo == "" {
+			unassigned.assigned += remainingWork(item)
+			continue
+		}
+		i := slices.IndexFunc(v.capacity.Members, func(member azure.MemberCapacity) bool {
+			if item.Assignee.UniqueName != "" {
+				return strings.EqualFold(member.Member.UniqueName, item.Assignee.UniqueName)
+			}
+			return member.Member.DisplayName == item.AssignedTo
+		})
+		if i < 0 {
+			i = slices.IndexFunc(rows, func(row capacityRow) bool { return row.name == item.AssignedTo })
+		}
+		if i < 0 {
+			rows = append(rows, capacityRow{name: item.AssignedTo})
+			i = len(rows) - 1
+		}
+		rows[i].assigned += remainingWork(item)
+	}
+	if unassigned.assigned > 0 {
+		rows = append(rows, unassigned)
+	}
+	return rows
+}
+
+// renderCapacity renders the capacity table with a bar of the assigned work against
+// the available capacity of each person, in red when they have more work than time
+func (v *SprintView) renderCapacity() string {
+	rows := v.capacityRows()
+	nameWidth := len("Member")
+	for _, row := range rows {
+		nameWidth = max(nameWidth, len(row.name))
+	}
+	nameWidth = min(nameWidth, 24)
+
+	var s strings.Builder
+	s.WriteString(DiscussionHeaderStyle.Render("Capacity"))
+	s.WriteString("\n")
+	s.WriteString(TitleDetailStyle.Render(fmt.Sprintf("%-*s  %7s  %8s  %9s  %8s", nameWidth, "Member", "Per day", "Days off", "Available", "Assigned")))
+	for _, row := range rows {
+		line := fmt.Sprintf("%-*s  %7s  %8d  %9s  %8s  ", nameWidth, truncate(row.name, nameWidth),
+			formatHours(row.perDay), row.daysOff, formatHours(row.available), formatHours(row.assigned))
+		s.WriteString("\n" + line + capacityBar(row.assigned, row.available))
+	}
+	return s.String()
+}
+
+// capacityBar renders the share of the available time the assigned work takes
+func capacityBar(assigned, available float64) string {
+	if available <= 0 {
+		if assigned > 0 {
+			return ErrorStyle.Render("no capacity")
+		}
+		return ""
+	}
+	filled := min(int(assigned/available*capacityBarWidth+0.5), capacityBarWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", capacityBarWidth-filled)
+	percent := fmt.Sprintf(" %d%%", int(assigned/available*100+0.5))
+	if assigned > available {
+		return ErrorStyle.Render(bar + percent)
+	}
+	return SuccessStyle.Render(bar) + CommentDateStyle.Render(percent)
+}
+
+// renderBurndown renders the remaining work of each working day as bars, with the
+// ideal burndown as dots where it is above the bars
+func (v *SprintView) renderBurndown() string {
+	var s strings.Builder
+	s.WriteString(DiscussionHeaderStyle.Render("Burndown"))
+	s.WriteString("\n")
+	if len(v.burndown) == 0 {
+		s.WriteString(CommentDateStyle.Render("The iteration has no working days scheduled."))
+		return s.String()
+	}
+
+	var top float64
+	for _, day := range v.burndown {
+		top = max(top, day.Remaining, day.Ideal)
+	}
+	if top == 0 {
+		s.WriteString(CommentDateStyle.Render("No remaining work has been estimated."))
+		return s.String()
+	}
+
+	label := len(formatHours(top))
+	for line := burndownHeight; line > 0; line-- {
+		// Each line covers the work above low up to high
+		low := top * float64(line-1) / burndownHeight
+		high := top * float64(line) / burndownHeight
+
+		axis := strings.Repeat(" ", label)
+		if line == burndownHeight {
+			axis = fmt.Sprintf("%*s", label, formatHours(top))
+		}
+		s.WriteString(CommentDateStyle.Render(axis + " │"))
+		for _, day := range v.burndown {
+			switch {
+			case !day.Future && day.Remaining > low:
+				s.WriteString(BurndownStyle.Render(" ██"))
+			case day.Ideal > low && day.Ideal <= high:
+				s.WriteString(CommentDateStyle.Render(" ··"))
+			default:
+				s.WriteString("   ")
+			}
+		}
+		s.WriteString("\n")
+	}
+
+	s.WriteString(CommentDateStyle.Render(fmt.Sprintf("%*s └%s", label, "0h", strings.Repeat("───", len(v.burndown)))))
+	s.WriteString("\n" + strings.Repeat(" ", label+2))
+	for _, day := range v.burndown {
+		s.WriteString(CommentDateStyle.Render(fmt.Sprintf("%3s", day.Day.Format("2"))))
+	}
+	s.WriteString("\n" + strings.Repeat(" ", label+2) +
+		BurndownStyle.Render(" ██") + CommentDateStyle.Render(" remaining  ··  ideal"))
+	return s.String()
+}
+
+func (v *SprintView) Update(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case sprintLoadedMsg:
+		v.loading = false
+		v.err = msg.err
+		if msg.err == nil {
+			v.iteration = msg.iteration
+			v.items = msg.items
+			v.capacity = msg.capacity
+			v.burndown = msg.burndown
+		}
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.view = &BacklogView{}
+			return m, m.view.Init(m)
+		case "r":
+			if !v.loading {
+				return m, v.load(m)
+			}
+		}
+	}
+	return m, nil
+}
@@ -26,6 +26,8 @@ const (
 	ColorDarkGray     = "237"     // Dark gray background
 	ColorBorderGray   = "240"     // Border gray
 	ColorDateGray     = "241"     // Date text gray
+	ColorGreen        = "42"      // Green for success messages
+	ColorRed          = "196"     // Red for error messages
 )
 
 // Common styles used throughout the application
@@ -46,6 +48,10 @@ var (
 			Background(lipgloss.Color(ColorPurpleBg)).
 			Padding(0, 2)
 
+	// WIPExceededStyle marks the header of a board column over its WIP limit
+	WIPExceededStyle = HeaderStyle.
+				Background(lipgloss.Color(ColorRed))
+
 	TitleDetailStyle = lipgloss.NewStyle().
 				Bold(true).
 				Foreground(lipgloss.Color(ColorWhite))
@@ -108,6 +114,28 @@ var (
 	InactiveTabStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color(ColorGray)).
 				Padding(0, 1)
+
+	// BurndownStyle draws the remaining work in the sprint burndown
+	BurndownStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(ColorPurpleViolet))
+
+	SuccessStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(ColorGreen))
+
+	ErrorStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color(ColorRed))
+
+	SearchMatchStyle = lipgloss.NewStyle().
+				Bold(true).
+				Underline(true).
+				Foreground(lipgloss.Color(ColorCyanGreen))
+
+	ErrorBannerStyle = lipgloss.NewStyle().
+				BorderStyle(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color(ColorRed)).
+				Padding(0, 1).
+				MarginBottom(1)
 )
 
 // GetWorkItemTypeColor returns the ANSI color for a work item type
@@ -0,0 +1,143 @@
+package views
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	tableHeaderStyle = lipgloss.NewStyle().
+				Bold(true).
+				Padding(0, 1)
+
+	tableHeaderBorderStyle = lipgloss.NewStyle().
+				BorderStyle(lipgloss.NormalBorder()).
+				BorderForeground(lipgloss.Color(ColorBorderGray)).
+				BorderBottom(true)
+
+	tableCellStyle = lipgloss.NewStyle().
+			Padding(0, 1)
+
+	tableSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color(ColorLightYellow)).
+				Background(lipgloss.Color(ColorPurpleBg))
+)
+
+// tableColumn describes a column of a dataTable
+type tableColumn struct {
+	Title string
+	Width int
+}
+
+// dataTable is a scrollable table like the bubbles table, except that cells can
+// be styled after they have been truncated to fit their column. Styling before
+// truncation is not possible with the bubbles table because it cuts escape sequences.
+type dataTable struct {
+	columns []tableColumn
+	rows    [][]string
+	cursor  int
+	offset  int
+	height  int
+
+	// styleCell styles the truncated text of a cell; it is not applied to the selected row
+	styleCell func(row, col int, text string) string
+}
+
+func newDataTable(columns []tableColumn, rows [][]string, height int) *dataTable {
+	t := &dataTable{
+		columns: columns,
+		height:  max(height, 1),
+	}
+	t.SetRows(rows)
+	return t
+}
+
+// SetRows replaces the rows of the table, keeping the cursor within bounds
+func (t *dataTable) SetRows(rows [][]string) {
+	t.rows = rows
+	t.SetCursor(t.cursor)
+}
+
+// SetHeight changes the number of rows shown, keeping the cursor in view
+func (t *dataTable) SetHeight(height int) {
+	t.height = max(height, 1)
+	t.SetCursor(t.cursor)
+}
+
+// Cursor returns the index of the selected row
+func (t *dataTable) Cursor() int {
+	return t.cursor
+}
+
+// SetCursor selects the row at index i and scrolls it into view
+func (t *dataTable) SetCursor(i int) {
+	t.cursor = max(min(i, len(t.rows)-1), 0)
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+	if t.cursor >= t.offset+t.height {
+		t.offset = t.cursor - t.height + 1
+	}
+	t.offset = max(min(t.offset, len(t.rows)-t.height), 0)
+}
+
+func (t *dataTable) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "k", "up":
+			t.SetCursor(t.cursor - 1)
+		case "j", "down":
+			t.SetCursor(t.cursor + 1)
+		case "pgup", "ctrl+u":
+			t.SetCursor(t.cursor - t.height)
+		case "pgdown", "ctrl+d":
+			t.SetCursor(t.cursor + t.height)
+		case "g", "home":
+			t.SetCursor(0)
+		case "G", "end":
+			t.SetCursor(len(t.rows) - 1)
+		}
+	}
+	return nil
+}
+
+func (t *dataTable) View() string {
+	headers := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		headers[i] = tableHeaderStyle.Width(col.Width + 2).Render(ansi.Truncate(col.Title, col.Width, "…"))
+	}
+
+	lines := []string{tableHeaderBorderStyle.Render(lipgloss.JoinHorizontal(lipgloss.Top, headers...))}
+	end := min(t.offset+t.height, len(t.rows))
+	for i := t.offset; i < end; i++ {
+		lines = append(lines, t.renderRow(i))
+	}
+	for i := end - t.offset; i < t.height; i++ {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (t *dataTable) renderRow(i int) string {
+	cells := make([]string, len(t.columns))
+	for j, col := range t.columns {
+		var text string
+		if j < len(t.rows[i]) {
+			text = ansi.Truncate(t.rows[i][j], col.Width, "…")
+		}
+		if t.styleCell != nil && i != t.cursor {
+			text = t.styleCell(i, j, text)
+		}
+		cells[j] = tableCellStyle.Width(col.Width + 2).MaxWidth(col.Width + 2).Render(text)
+	}
+
+	row := strings.Join(cells, "")
+	if i == t.cursor {
+		return tableSelectedStyle.Render(row)
+	}
+	return row
+}
@@ -0,0 +1,103 @@
+package views
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// numberedRows returns n rows holding their own index
+func numberedRows(n int) [][]string {
+	rows := make([][]string, n)
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i)}
+	}
+	return rows
+}
+
+// TestDataTableScroll checks that the cursor stays within the rows and in view
+func TestDataTableScroll(t *testing.T) {
+	table := newDataTable([]tableColumn{{Title: "#", Width: 4}}, numberedRows(10), 3)
+
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	tests := []struct {
+		key            tea.KeyMsg
+		cursor, offset int
+	}{
+		{runes("k"), 0, 0},
+		{runes("j"), 1, 0},
+		{tea.KeyMsg{Type: tea.KeyDown}, 2, 0},
+		{runes("j"), 3, 1},
+		{tea.KeyMsg{Type: tea.KeyCtrlD}, 6, 4},
+		{runes("G"), 9, 7},
+		{runes("j"), 9, 7},
+		{tea.KeyMsg{Type: tea.KeyPgUp}, 6, 6},
+		{tea.KeyMsg{Type: tea.KeyUp}, 5, 5},
+		{runes("g"), 0, 0},
+	}
+
+	for _, tt := range tests {
+		table.Update(tt.key)
+		if table.Cursor() != tt.cursor || table.offset != tt.offset {
+			t.Errorf("after %q: cursor %d, offset %d, want %d, %d", tt.key, table.Cursor(), table.offset, tt.cursor, tt.offset)
+		}
+	}
+}
+
+// TestDataTableResize checks that replacing the rows or the height keeps the cursor
+// on a row and the rows filling the table
+func TestDataTableResize(t *testing.T) {
+	tests := []struct {
+		name           string
+		resize         func(*dataTable)
+		cursor, offset int
+	}{
+		{"fewer rows", func(d *dataTable) { d.SetRows(numberedRows(5)) }, 4, 2},
+		{"no rows", func(d *dataTable) { d.SetRows(nil) }, 0, 0},
+		{"taller", func(d *dataTable) { d.SetHeight(6) }, 8, 4},
+		{"shorter", func(d *dataTable) { d.SetHeight(1) }, 8, 8},
+		{"no height", func(d *dataTable) { d.SetHeight(-2) }, 8, 8},
+		{"cursor past the end", func(d *dataTable) { d.SetCursor(20) }, 9, 7},
+	}
+
+	for _, tt := range tests {
+		table := newDataTable([]tableColumn{{Title: "#", Width: 4}}, numberedRows(10), 3)
+		table.SetCursor(8)
+		tt.resize(table)
+		if table.Cursor() != tt.cursor || table.offset != tt.offset {
+			t.Errorf("%s: cursor %d, offset %d, want %d, %d", tt.name, table.Cursor(), table.offset, tt.cursor, tt.offset)
+		}
+	}
+}
+
+// TestDataTableView checks that cells are truncated to their column before they are
+// styled, and that the selected row is not styled
+func TestDataTableView(t *testing.T) {
+	table := newDataTable(
+		[]tableColumn{{Title: "ID", Width: 4}, {Title: "Title", Width: 8}},
+		[][]string{{"1", "Export to CSV"}, {"2", "Login"}, {"3", "Search"}},
+		4,
+	)
+	table.SetCursor(1)
+	var styled []string
+	table.styleCell = func(row, col int, text string) string {
+		styled = append(styled, text)
+		return strings.ToUpper(text)
+	}
+
+	lines := strings.Split(ansi.Strip(table.View()), "\n")
+	// The header is followed by its border, and empty lines fill the height
+	if len(lines) != 6 {
+		t.Fatalf("got %d lines, want 6:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	want := []string{"1", "Export …", "3", "Search"}
+	if strings.Join(styled, "|") != strings.Join(want, "|") {
+		t.Errorf("styled %q, want %q", styled, want)
+	}
+	if !strings.Contains(lines[2], "EXPORT …") || !strings.Contains(lines[3], "Login") || !strings.Contains(lines[4], "SEARCH") {
+		t.Errorf("rows:\n%s", strings.Join(lines[2:], "\n"))
+	}
+}
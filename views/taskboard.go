@@ -0,0 +1,370 @@
+package views
+
+import (
+	"fazure/azure"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// taskboardFields are the fields shown on taskboard cards
+var taskboardFields = []string{azure.FieldTitle, azure.FieldAssignedTo, azure.FieldState, azure.FieldPriority}
+
+// categoryOrder orders state categories from left to right on the taskboard
+var categoryOrder = []string{azure.CategoryProposed, azure.CategoryInProgress, azure.CategoryResolved, azure.CategoryCompleted}
+
+// taskboardLoadedMsg is sent when the work items of the current iteration and
+// the workflows of their types have been fetched
+type taskboardLoadedMsg struct {
+	iteration *azure.ClassificationNode
+	items     []azure.WorkItem
+	workflows map[azure.WorkItemType]*azure.Workflow
+	err       error
+}
+
+// cardMovedMsg is sent when a card has been moved to another column
+type cardMovedMsg struct {
+	from string
+	item *azure.WorkItem
+	err  error
+}
+
+// TaskboardView shows the work items of the team's current iteration as cards
+// in one column per state
+type TaskboardView struct {
+	iteration *azure.ClassificationNode
+	items     []azure.WorkItem
+	workflows map[azure.WorkItemType]*azure.Workflow
+	columns   []string
+	cards     [][]int
+	col       int
+	row       int
+	loading   bool
+	moving    bool
+	err       error
+	status    string
+	statusErr error
+}
+
+func (v *TaskboardView) Init(m Model) tea.Cmd {
+	return v.load(m)
+}
+
+// load fetches the current iteration, its work items and their workflows
+func (v *TaskboardView) load(m Model) tea.Cmd {
+	v.loading = true
+	v.err = nil
+	return func() tea.Msg {
+		iteration, err := m.azure.GetCurrentIteration()
+		if err != nil {
+			return taskboardLoadedMsg{err: err}
+		}
+		items, err := m.azure.QueryWorkItems(azure.QueryParams{
+			IterationPath: iteration.Path,
+			ExcludeStates: []string{"Removed"},
+			OrderBy:       []azure.SortKey{{Field: azure.SortByPriority}, {Field: azure.SortByID}},
+			Fields:        taskboardFields,
+		})
+		if err != nil {
+			return taskboardLoadedMsg{err: err}
+		}
+
+		workflows := map[azure.WorkItemType]*azure.Workflow{}
+		for _, item := range items {
+			if _, ok := workflows[item.Type]; ok {
+				continue
+			}
+			// Without a workflow the columns fall back to the states of the cards
+			workflow, _ := m.azure.GetWorkflow(item.Type)
+			workflows[item.Type] = workflow
+		}
+		return taskboardLoadedMsg{iteration: iteration, items: items, workflows: workflows}
+	}
+}
+
+// taskboardColumns returns the states of the workflows in category order, leaving out
+// removed states, followed by any other states of the cards
+func taskboardColumns(workflows map[azure.WorkItemType]*azure.Workflow, items []azure.WorkItem) []string {
+	var columns []string
+	rank := map[string]int{}
+	for _, itemType := range slices.Sorted(maps.Keys(workflows)) {
+		workflow := workflows[itemType]
+		if workflow == nil {
+			continue
+		}
+		for _, state := range workflow.States {
+			if state.Category == azure.CategoryRemoved || slices.Contains(columns, state.Name) {
+				continue
+			}
+			columns = append(columns, state.Name)
+			rank[state.Name] = slices.Index(categoryOrder, state.Category)
+			if rank[state.Name] < 0 {
+				rank[state.Name] = len(categoryOrder)
+			}
+		}
+	}
+	slices.SortStableFunc(columns, func(a, b string) int {
+		return rank[a] - rank[b]
+	})
+
+	for _, item := range items {
+		if !slices.Contains(columns, item.State) {
+			columns = append(columns, item.State)
+		}
+	}
+	return columns
+}
+
+// regroup sorts the cards into their columns, keeping the selected card under the cursor
+func (v *TaskboardView) regroup() {
+	selected := v.selected()
+
+	v.columns = taskboardColumns(v.workflows, v.items)
+	v.cards = make([][]int, len(v.columns))
+	for i, item := range v.items {
+		col := slices.Index(v.columns, item.State)
+		v.cards[col] = append(v.cards[col], i)
+	}
+
+	v.col = min(v.col, max(len(v.columns)-1, 0))
+	v.row = 0
+	for col, cards := range v.cards {
+		for row, index := range cards {
+			if selected != nil && v.items[index].ID == selected.ID {
+				v.col, v.row = col, row
+			}
+		}
+	}
+}
+
+// selected returns the work item of the card under the cursor, if any
+func (v *TaskboardView) selected() *azure.WorkItem {
+	if v.col >= len(v.cards) || v.row >= len(v.cards[v.col]) {
+		return nil
+	}
+	return &v.items[v.cards[v.col][v.row]]
+}
+
+func (v *TaskboardView) View(m Model) string {
+	var s strings.Builder
+	s.WriteString(TitleStyle.Render("Taskboard"))
+	s.WriteString("\n")
+	if v.iteration != nil {
+		s.WriteString(CommentDateStyle.Render(iterationSummary(v.iteration)))
+	}
+	s.WriteString("\n\n")
+
+	switch {
+	case v.err != nil:
+		s.WriteString(errorBanner(v.err, getContentWidth(m.terminalWidth), true))
+		s.WriteString("\n\n")
+		s.WriteString(HelpStyle.Render("Press 'esc' to go back"))
+		return s.String()
+	case v.loading && v.items == nil:
+		s.WriteString("Loading the current iteration...\n\n")
+		s.WriteString(HelpStyle.Render("Press 'esc' to go back"))
+		return s.String()
+	case len(v.items) == 0:
+		s.WriteString("No work items in the current iteration.\n\n")
+		s.WriteString(HelpStyle.Render("Press 'r' to refresh • 'esc' to go back"))
+		return s.String()
+	}
+
+	s.WriteString(v.renderColumns(m))
+	s.WriteString("\n")
+
+	if v.statusErr != nil {
+		s.WriteString(ErrorStyle.Render(describeError(v.statusErr)) + "\n")
+	} else if v.status != "" {
+		s.WriteString(SuccessStyle.Render(v.status) + "\n")
+	}
+	s.WriteString(HelpStyle.Render("Press 'h'/'l' to move a card • arrows to select • 'enter' to view details • 'r' to refresh • 'esc' for the backlog"))
+	return s.String()
+}
+
+// iterationSummary describes an iteration and its dates in a single line
+func iterationSummary(iteration *azure.ClassificationNode) string {
+	if iteration.StartDate.IsZero() {
+		return iteration.Path
+	}
+	return fmt.Sprintf("%s • %s - %s", iteration.Path,
+		iteration.StartDate.Format("Jan 2"), iteration.FinishDate.Format("Jan 2"))
+}
+
+// renderColumns renders the columns side by side, scrolling the selected column to the cursor
+func (v *TaskboardView) renderColumns(m Model) string {
+	width := max((m.terminalWidth-8)/len(v.columns)-2, 16)
+	// Each card takes three lines
+	visible := max((m.terminalHeight-14)/3, 1)
+
+	rendered := make([]string, len(v.columns))
+	for col, state := range v.columns {
+		header := fmt.Sprintf("%s (%d)", state, len(v.cards[col]))
+		lines := []string{
+			HeaderStyle.Width(width).Render(truncate(header, width-4)),
+		}
+
+		offset := 0
+		if col == v.col {
+			offset = max(v.row-visible+1, 0)
+		}
+		if offset > 0 {
+			lines = append(lines, CommentDateStyle.Render(fmt.Sprintf("  ↑ %d more", offset)))
+		}
+		end := min(offset+visible, len(v.cards[col]))
+		for row := offset; row < end; row++ {
+			item := v.items[v.cards[col][row]]
+			lines = append(lines, renderCard(item, width, col == v.col && row == v.row)...)
+		}
+		if end < len(v.cards[col]) {
+			lines = append(lines, CommentDateStyle.Render(fmt.Sprintf("  ↓ %d more", len(v.cards[col])-end)))
+		}
+
+		rendered[col] = lipgloss.NewStyle().Width(width).MarginRight(2).Render(strings.Join(lines, "\n"))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+// renderCard renders a work item as the lines of a card, marking the selected card
+func renderCard(item azure.WorkItem, width int, selected bool) []string {
+	marker := "  "
+	if selected {
+		marker = ActiveOptionStyle.Render("▌ ")
+	}
+
+	id := fmt.Sprintf("#%d", item.ID)
+	title := truncate(item.Title, max(width-len(id)-3, 4))
+	assignee := item.AssignedTo
+	if assignee == "" {
+		assignee = unassigned
+	}
+	if selected {
+		title = TitleDetailStyle.Render(title)
+	}
+	return []string{
+		"",
+		marker + GetWorkItemTypeStyle(item.Type).Render(id) + " " + title,
+		marker + CommentDateStyle.Render(truncate(assignee, width-2)),
+	}
+}
+
+func (v *TaskboardView) Update(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case taskboardLoadedMsg:
+		v.loading = false
+		v.err = msg.err
+		if msg.err == nil {
+			v.iteration = msg.iteration
+			v.items = msg.items
+			v.workflows = msg.workflows
+			v.regroup()
+		}
+		return m, nil
+	case cardMovedMsg:
+		v.moved(msg)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.view = &BacklogView{}
+			return m, m.view.Init(m)
+		case "r":
+			v.status, v.statusErr = "", nil
+			return m, v.load(m)
+		}
+		if v.err != nil || len(v.columns) == 0 {
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "left":
+			v.selectColumn(v.col - 1)
+		case "right":
+			v.selectColumn(v.col + 1)
+		case "k", "up":
+			if v.row > 0 {
+				v.row--
+			}
+		case "j", "down":
+			if v.row < len(v.cards[v.col])-1 {
+				v.row++
+			}
+		case "h":
+			return m, v.move(m, -1)
+		case "l":
+			return m, v.move(m, 1)
+		case "enter":
+			if item := v.selected(); item != nil {
+				m.view = &DetailsView{item: item, returnTo: v}
+				return m, m.view.Init(m)
+			}
+		}
+	}
+	return m, nil
+}
+
+// selectColumn moves the cursor to another column, keeping its row where possible
+func (v *TaskboardView) selectColumn(col int) {
+	if col < 0 || col >= len(v.columns) {
+		return
+	}
+	v.col = col
+	v.row = min(v.row, max(len(v.cards[col])-1, 0))
+}
+
+// move transitions the selected card to the nearest column in the given direction
+// whose state its workflow allows it to move to
+func (v *TaskboardView) move(m Model, dir int) tea.Cmd {
+	item := v.selected()
+	if item == nil || v.moving {
+		return nil
+	}
+
+	workflow := v.workflows[item.Type]
+	target := ""
+	for col := v.col + dir; col >= 0 && col < len(v.columns); col += dir {
+		state := v.columns[col]
+		if workflow == nil || workflow.CanMove(item.State, state) {
+			target = state
+			break
+		}
+	}
+	if target == "" {
+		v.status = ""
+		v.statusErr = fmt.Errorf("%s #%d cannot move any further from %s", item.Type, item.ID, item.State)
+		return nil
+	}
+
+	id, rev, from := item.ID, item.Rev, item.State
+	v.moving = true
+	v.status = fmt.Sprintf("Moving #%d to %s...", id, target)
+	v.statusErr = nil
+	return func() tea.Msg {
+		updated, err := m.azure.UpdateWorkItem(id, rev, []azure.PatchOperation{azure.SetField(azure.FieldState, target)})
+		return cardMovedMsg{from: from, item: updated, err: err}
+	}
+}
+
+// moved shows the outcome of moving a card and puts it in its new column
+func (v *TaskboardView) moved(msg cardMovedMsg) {
+	v.moving = false
+	if msg.err != nil {
+		v.status = ""
+		v.statusErr = msg.err
+		return
+	}
+
+	v.status = fmt.Sprintf("#%d %s → %s", msg.item.ID, msg.from, msg.item.State)
+	v.statusErr = nil
+	for i := range v.items {
+		if v.items[i].ID == msg.item.ID {
+			v.items[i] = *msg.item
+		}
+	}
+	// The cursor follows the card to its new column
+	v.regroup()
+}
@@ -0,0 +1,204 @@
+package views
+
+import (
+	"fazure/azure"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// treeGuide is a piece of the indentation in front of a tree row, colored by
+// the type of the work item whose children it connects
+type treeGuide struct {
+	text  string
+	color string
+}
+
+// treeNode is the position of a work item in the backlog tree
+type treeNode struct {
+	guides      []treeGuide
+	children    int
+	descendants int
+	collapsed   bool
+}
+
+// prefix returns the indentation and expand marker of the node
+func (n treeNode) prefix() string {
+	var s strings.Builder
+	for _, g := range n.guides {
+		s.WriteString(g.text)
+	}
+	return s.String()
+}
+
+// suffix returns the rollup shown after the title of the node
+func (n treeNode) suffix() string {
+	if n.descendants == 0 {
+		return ""
+	}
+	return " (" + pluralize(n.descendants, "item", "items") + ")"
+}
+
+// buildTree arranges the work items at the given indices by their parent links,
+// keeping the order of indices among siblings. Items whose parent is not among
+// them become roots. It returns the indices of the rows to show, skipping the
+// descendants of collapsed items, and the tree node of each of those rows.
+func buildTree(items []azure.WorkItem, indices []int, collapsed map[int]bool) ([]int, []treeNode) {
+	byID := make(map[int]int, len(indices))
+	for _, i := range indices {
+		byID[items[i].ID] = i
+	}
+
+	children := map[int][]int{}
+	var roots []int
+	for _, i := range indices {
+		if p, ok := byID[items[i].ParentID()]; ok && p != i {
+			children[p] = append(children[p], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+
+	var rows []int
+	var nodes []treeNode
+	visited := map[int]bool{}
+	var count func(i int, seen map[int]bool) int
+	count = func(i int, seen map[int]bool) int {
+		n := 0
+		for _, c := range children[i] {
+			if !seen[c] && !visited[c] {
+				seen[c] = true
+				n += 1 + count(c, seen)
+			}
+		}
+		return n
+	}
+
+	var walk func(i int, guides []treeGuide, continues []treeGuide)
+	walk = func(i int, guides []treeGuide, continues []treeGuide) {
+		visited[i] = true
+		item := items[i]
+		color := GetWorkItemTypeColor(item.Type)
+		// The only children already shown are the ancestors of a parent cycle
+		kids := slices.DeleteFunc(slices.Clone(children[i]), func(c int) bool { return visited[c] })
+
+		node := treeNode{
+			children:    len(kids),
+			descendants: count(i, map[int]bool{i: true}),
+			collapsed:   collapsed[item.ID],
+		}
+		marker := "  "
+		if node.children > 0 {
+			marker = "▾ "
+			if node.collapsed {
+				marker = "▸ "
+			}
+		}
+		node.guides = append(append([]treeGuide{}, guides...), treeGuide{text: marker, color: color})
+		rows = append(rows, i)
+		nodes = append(nodes, node)
+
+		if node.collapsed {
+			return
+		}
+		for k, c := range kids {
+			branch, next := "├─", "│ "
+			if k == len(kids)-1 {
+				branch, next = "└─", "  "
+			}
+			walk(c,
+				append(append([]treeGuide{}, continues...), treeGuide{text: branch, color: color}),
+				append(append([]treeGuide{}, continues...), treeGuide{text: next, color: color}))
+		}
+	}
+
+	for _, i := range roots {
+		walk(i, nil, nil)
+	}
+	// Items in a parent cycle have no root; show them at the top level
+	for _, i := range indices {
+		if !visited[i] && !hasVisitedAncestor(items, byID, visited, i) {
+			walk(i, nil, nil)
+		}
+	}
+	return rows, nodes
+}
+
+// hasVisitedAncestor reports whether an ancestor of the item at index i has been
+// shown, in which case the item is hidden under a collapsed node
+func hasVisitedAncestor(items []azure.WorkItem, byID map[int]int, visited map[int]bool, i int) bool {
+	seen := map[int]bool{i: true}
+	for {
+		p, ok := byID[items[i].ParentID()]
+		if !ok || seen[p] {
+			return false
+		}
+		if visited[p] {
+			return true
+		}
+		seen[p] = true
+		i = p
+	}
+}
+
+// styleTreeCell colors the guides of a truncated tree cell, highlights search
+// matches in the title and dims the rollup after it
+func styleTreeCell(text string, node treeNode, titleLen int, positions []int) string {
+	runes := []rune(text)
+	var s strings.Builder
+	n := 0
+	for _, g := range node.guides {
+		take := min(len([]rune(g.text)), len(runes)-n)
+		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(g.color)).Render(string(runes[n : n+take])))
+		n += take
+	}
+
+	rest := runes[n:]
+	title := min(titleLen, len(rest))
+	s.WriteString(highlightRunes(string(rest[:title]), positions, func(s string) string {
+		return SearchMatchStyle.Render(s)
+	}))
+	if title < len(rest) {
+		s.WriteString(CommentDateStyle.Render(string(rest[title:])))
+	}
+	return s.String()
+}
+
+// updateTree handles the keys that expand and collapse tree rows
+func (v *BacklogView) updateTree(msg tea.KeyMsg) bool {
+	row := v.table.Cursor()
+	if row >= len(v.nodes) {
+		return false
+	}
+	item := v.workItems[v.visible[row]]
+	node := v.nodes[row]
+
+	switch msg.String() {
+	case " ":
+		if node.children > 0 {
+			v.collapsed[item.ID] = !v.collapsed[item.ID]
+		}
+	case "l", "right":
+		delete(v.collapsed, item.ID)
+	case "h", "left":
+		if node.children > 0 && !node.collapsed {
+			v.collapsed[item.ID] = true
+			break
+		}
+		// Move to the parent row
+		for i := row - 1; i >= 0; i-- {
+			if v.workItems[v.visible[i]].ID == item.ParentID() {
+				v.table.SetCursor(i)
+				break
+			}
+		}
+		return true
+	default:
+		return false
+	}
+
+	v.refreshRows()
+	return true
+}
@@ -0,0 +1,140 @@
+package views
+
+import (
+	"fazure/azure"
+	"slices"
+	"testing"
+)
+
+// treeItem returns a work item with the given parent, or none if parent is 0
+func treeItem(id, parent int) azure.WorkItem {
+	item := azure.WorkItem{ID: id, Type: azure.UserStory}
+	if parent != 0 {
+		item.Relations = []azure.Relation{{Rel: azure.RelParent, TargetID: parent}}
+	}
+	return item
+}
+
+// TestBuildTree checks the rows of the tree and the guides in front of them
+func TestBuildTree(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       []azure.WorkItem
+		indices     []int
+		collapsed   map[int]bool
+		ids         []int
+		prefixes    []string
+		descendants []int
+	}{
+		{
+			name:        "nested",
+			items:       []azure.WorkItem{treeItem(1, 0), treeItem(2, 1), treeItem(3, 1), treeItem(4, 2)},
+			ids:         []int{1, 2, 4, 3},
+			prefixes:    []string{"▾ ", "├─▾ ", "│ └─  ", "└─  "},
+			descendants: []int{3, 1, 0, 0},
+		},
+		{
+			name:        "sibling order follows the indices",
+			items:       []azure.WorkItem{treeItem(1, 0), treeItem(2, 1), treeItem(3, 1)},
+			indices:     []int{2, 0, 1},
+			ids:         []int{1, 3, 2},
+			prefixes:    []string{"▾ ", "├─  ", "└─  "},
+			descendants: []int{2, 0, 0},
+		},
+		{
+			name:        "orphans",
+			items:       []azure.WorkItem{treeItem(1, 99), treeItem(2, 1), treeItem(3, 3)},
+			ids:         []int{1, 2, 3},
+			prefixes:    []string{"▾ ", "└─  ", "  "},
+			descendants: []int{1, 0, 0},
+		},
+		{
+			name:        "parent filtered out",
+			items:       []azure.WorkItem{treeItem(1, 0), treeItem(2, 1), treeItem(3, 2)},
+			indices:     []int{0, 2},
+			ids:         []int{1, 3},
+			prefixes:    []string{"  ", "  "},
+			descendants: []int{0, 0},
+		},
+		{
+			name:        "parent cycle",
+			items:       []azure.WorkItem{treeItem(1, 3), treeItem(2, 1), treeItem(3, 2), treeItem(4, 0)},
+			ids:         []int{4, 1, 2, 3},
+			prefixes:    []string{"  ", "▾ ", "└─▾ ", "  └─  "},
+			descendants: []int{0, 2, 1, 0},
+		},
+		{
+			name:        "collapsed subtree",
+			items:       []azure.WorkItem{treeItem(1, 0), treeItem(2, 1), treeItem(3, 2), treeItem(4, 0)},
+			collapsed:   map[int]bool{2: true},
+			ids:         []int{1, 2, 4},
+			prefixes:    []string{"▾ ", "└─▸ ", "  "},
+			descendants: []int{2, 1, 0},
+		},
+		{
+			name:        "collapsed cycle",
+			items:       []azure.WorkItem{treeItem(1, 2), treeItem(2, 1)},
+			collapsed:   map[int]bool{1: true},
+			ids:         []int{1},
+			prefixes:    []string{"▸ "},
+			descendants: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		indices := tt.indices
+		if indices == nil {
+			for i := range tt.items {
+				indices = append(indices, i)
+			}
+		}
+		rows, nodes := buildTree(tt.items, indices, tt.collapsed)
+
+		var ids, descendants []int
+		var prefixes []string
+		for i, row := range rows {
+			ids = append(ids, tt.items[row].ID)
+			prefixes = append(prefixes, nodes[i].prefix())
+			descendants = append(descendants, nodes[i].descendants)
+		}
+		if !slices.Equal(ids, tt.ids) {
+			t.Errorf("%s: rows %v, want %v", tt.name, ids, tt.ids)
+			continue
+		}
+		if !slices.Equal(prefixes, tt.prefixes) {
+			t.Errorf("%s: prefixes %q, want %q", tt.name, prefixes, tt.prefixes)
+		}
+		if !slices.Equal(descendants, tt.descendants) {
+			t.Errorf("%s: descendants %v, want %v", tt.name, descendants, tt.descendants)
+		}
+	}
+}
+
+// TestHasVisitedAncestor checks the ancestor walk, which must stop at parent cycles
+func TestHasVisitedAncestor(t *testing.T) {
+	chain := []azure.WorkItem{treeItem(1, 0), treeItem(2, 1), treeItem(3, 2)}
+	cycle := []azure.WorkItem{treeItem(1, 3), treeItem(2, 1), treeItem(3, 2)}
+	byID := map[int]int{1: 0, 2: 1, 3: 2}
+
+	tests := []struct {
+		name    string
+		items   []azure.WorkItem
+		visited map[int]bool
+		i       int
+		want    bool
+	}{
+		{"grandparent shown", chain, map[int]bool{0: true}, 2, true},
+		{"parent shown", chain, map[int]bool{1: true}, 2, true},
+		{"nothing shown", chain, map[int]bool{}, 2, false},
+		{"only the item shown", chain, map[int]bool{2: true}, 2, false},
+		{"root", chain, map[int]bool{1: true}, 0, false},
+		{"cycle not shown", cycle, map[int]bool{}, 0, false},
+		{"cycle shown", cycle, map[int]bool{1: true}, 0, true},
+	}
+
+	for _, tt := range tests {
+		if got := hasVisitedAncestor(tt.items, byID, tt.visited, tt.i); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package views
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 func wrapText(text string, width int) string {
 	if width <= 0 {
@@ -34,3 +37,20 @@ func wrapText(text string, width int) string {
 
 	return strings.Join(lines, "\n")
 }
+
+// truncate shortens text to at most width runes, marking the cut with an ellipsis
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if width <= 0 || len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// pluralize formats a count followed by the singular or plural form of a noun
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}